@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+// rollAbility picks one of a wild Pokemon's regular abilities at random.
+// Hidden abilities are never rolled.
+func rollAbility(rng *rand.Rand, data pokeapi.Pokemon) string {
+	var regular []string
+	for _, a := range data.Abilities {
+		if !a.IsHidden {
+			regular = append(regular, a.Ability.Name)
+		}
+	}
+	if len(regular) == 0 {
+		return ""
+	}
+	return regular[rng.Intn(len(regular))]
+}
+
+// commandAbility shows what an ability does and which Pokemon can have it.
+func commandAbility(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no ability specified")
+	}
+	ability, err := cfg.client.GetAbility(ctx, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no such ability: %s", args[0])
+	} else if err != nil {
+		return fmt.Errorf("error getting ability: %w", err)
+	}
+	fmt.Printf("Name: %s\n", displayName(ability.Names, ability.Name))
+	for _, e := range ability.EffectEntries {
+		if e.Language.Name == language {
+			fmt.Printf("Effect: %s\n", e.ShortEffect)
+			break
+		}
+	}
+	if len(ability.Pokemon) == 0 {
+		return nil
+	}
+	fmt.Println("Pokemon:")
+	for _, p := range ability.Pokemon {
+		if p.IsHidden {
+			fmt.Printf(" - %s (hidden)\n", p.Pokemon.Name)
+		} else {
+			fmt.Printf(" - %s\n", p.Pokemon.Name)
+		}
+	}
+	return nil
+}
+
+// commandNature shows the stats a nature raises and lowers by 10%, and the
+// flavors a Pokemon with it likes and hates.
+func commandNature(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no nature specified")
+	}
+	nature, err := cfg.client.GetNature(ctx, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no such nature: %s", args[0])
+	} else if err != nil {
+		return fmt.Errorf("error getting nature: %w", err)
+	}
+	fmt.Printf("Name: %s\n", displayName(nature.Names, nature.Name))
+	if nature.IncreasedStat == nil || nature.DecreasedStat == nil {
+		fmt.Println("No stat changes")
+		return nil
+	}
+	fmt.Printf("Increases: %s (+10%%)\nDecreases: %s (-10%%)\n", nature.IncreasedStat.Name, nature.DecreasedStat.Name)
+	if nature.LikesFlavor != nil && nature.HatesFlavor != nil {
+		fmt.Printf("Likes %s food, hates %s food\n", nature.LikesFlavor.Name, nature.HatesFlavor.Name)
+	}
+	return nil
+}
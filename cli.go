@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/faust-m/pokedexcli/internal/lineedit"
+	"github.com/faust-m/pokedexcli/internal/output"
+)
+
+// Exit statuses for non-interactive use.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitInterrupted = 130
+)
+
+const (
+	prompt = "Pokedex > "
+	// historySize is the number of REPL lines kept in the history file.
+	historySize = 1000
+)
+
+var errUnknownCommand = errors.New("unknown command")
+
+// run starts the REPL when there are no arguments. Otherwise it runs the
+// command given as arguments, or with -c a sequence of commands separated by
+// semicolons, and returns the exit status.
+func run(cfg *config, args []string) int {
+	switch {
+	case len(args) == 0:
+		lines := scanLines(os.Stdin)
+		if lineedit.IsTerminal(int(os.Stdin.Fd())) {
+			lines = editLines(cfg, os.Stdin)
+			// Fetch the species index now so Pokemon names complete later.
+			go cfg.client.GetSpeciesIndex(context.Background())
+		}
+		return repl(cfg, lines, newInterrupter())
+	case args[0] == "-c" && len(args) != 2:
+		fmt.Fprintln(os.Stderr, `usage: pokedexcli -c "command; command"`)
+		return exitUsage
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if args[0] == "-c" {
+		return runScript(ctx, cfg, args[1])
+	}
+	return report(execute(ctx, cfg, cleanInput(strings.Join(args, " "))))
+}
+
+// runScript runs each command in script in turn, stopping at the first that
+// fails.
+func runScript(ctx context.Context, cfg *config, script string) int {
+	for _, line := range strings.Split(script, ";") {
+		input := cleanInput(line)
+		if len(input) == 0 {
+			continue
+		}
+		if status := report(execute(ctx, cfg, input)); status != exitOK {
+			return status
+		}
+	}
+	return exitOK
+}
+
+// lineReader shows a prompt and reads a line of input, returning io.EOF
+// once the input ends and lineedit.ErrInterrupted if Ctrl-C is pressed.
+type lineReader func(prompt string) (string, error)
+
+// scanLines reads lines from r as they are, for input that is not a
+// terminal.
+func scanLines(r io.Reader) lineReader {
+	scanner := bufio.NewScanner(r)
+	return func(prompt string) (string, error) {
+		fmt.Print(prompt)
+		if !scanner.Scan() {
+			fmt.Println()
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.EOF
+		}
+		return scanner.Text(), nil
+	}
+}
+
+// editLines reads lines from the terminal f with a line editor, keeping the
+// history next to the save file. The terminal is only in raw mode while a
+// line is being read.
+func editLines(cfg *config, f *os.File) lineReader {
+	var historyPath string
+	if cfg.savePath != "" {
+		historyPath = filepath.Join(filepath.Dir(cfg.savePath), "history")
+	}
+	history, err := lineedit.LoadHistory(historyPath, historySize)
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+	editor := lineedit.New(f, os.Stdout, history, func(line string) []string {
+		return complete(cfg, line)
+	})
+	return func(prompt string) (string, error) {
+		restore, err := lineedit.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return "", err
+		}
+		defer restore()
+		return editor.ReadLine(prompt)
+	}
+}
+
+// repl reads and runs commands until the input ends, returning the exit
+// status.
+func repl(cfg *config, readLine lineReader, interrupts *interrupter) int {
+	for {
+		line, err := readLine(prompt)
+		switch {
+		case errors.Is(err, io.EOF):
+			return exitOK
+		case errors.Is(err, lineedit.ErrInterrupted):
+			fmt.Println("(use exit to quit)")
+			continue
+		case err != nil:
+			fmt.Println("Error:", err)
+			return exitError
+		}
+		input := cleanInput(line)
+		if len(input) == 0 {
+			continue
+		}
+		ctx, done := interrupts.start()
+		err = execute(ctx, cfg, input)
+		done()
+		switch {
+		case errors.Is(err, errUnknownCommand):
+			fmt.Println("Unknown command")
+		case errors.Is(err, context.Canceled):
+			fmt.Println("Interrupted")
+		case err != nil:
+			fmt.Println("Error:", err)
+		}
+	}
+}
+
+// execute dispatches one command, its name followed by its arguments. An
+// --output format, or --json as a shorthand for --output json, applies to
+// this command only, and only commands with structured results accept one.
+func execute(ctx context.Context, cfg *config, input []string) error {
+	name, input, err := flagValue(input, "--output")
+	if err != nil {
+		return err
+	}
+	asJSON, input := hasFlag(input, "--json")
+	if asJSON {
+		name = string(output.JSON)
+	}
+	if len(input) == 0 {
+		return fmt.Errorf("%w: no command given", errUnknownCommand)
+	}
+	command, ok := cmds[input[0]]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownCommand, input[0])
+	}
+	if command.result == nil {
+		if name != "" {
+			return fmt.Errorf("%s only writes text, it has no --output formats", input[0])
+		}
+		return command.callback(ctx, cfg, input[1:]...)
+	}
+	if name != "" {
+		format, err := output.Parse(name)
+		if err != nil {
+			return err
+		}
+		cfg.format = format
+		defer func() { cfg.format = "" }()
+	}
+	return render(ctx, cfg, command.result, input[1:]...)
+}
+
+// report prints a failed command's error to standard error and returns the
+// exit status for it.
+func report(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUnknownCommand):
+		fmt.Fprintf(os.Stderr, "Error: %v, use help to list commands\n", err)
+		return exitUsage
+	case errors.Is(err, context.Canceled):
+		fmt.Fprintln(os.Stderr, "Interrupted")
+		return exitInterrupted
+	default:
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return exitError
+	}
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/faust-m/pokedexcli/internal/lineedit"
+	"github.com/faust-m/pokedexcli/internal/output"
+)
+
+// captureStdout returns what f writes to standard output.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+	f()
+	w.Close()
+	return <-out
+}
+
+func TestRunScript(t *testing.T) {
+	cfg := newTestConfig(t)
+	if status := runScript(context.Background(), cfg, "travel eterna-forest; where;"); status != exitOK {
+		t.Errorf("expected travelling to succeed, got status %d", status)
+		t.Fail()
+	}
+	if status := runScript(context.Background(), cfg, "travel atlantis; travel canalave-city"); status != exitError {
+		t.Errorf("expected travelling to atlantis to fail, got status %d", status)
+		t.Fail()
+	}
+	if here := position(cfg).Location; here != "eterna-forest" {
+		t.Errorf("expected the script to stop at the first failure, but moved to %s", here)
+		t.Fail()
+	}
+	if status := runScript(context.Background(), cfg, "fly eterna-forest"); status != exitUsage {
+		t.Errorf("expected an unknown command to be a usage error, got status %d", status)
+		t.Fail()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if status := runScript(ctx, cfg, "travel canalave-city"); status != exitInterrupted {
+		t.Errorf("expected an interrupted command to exit %d, got %d", exitInterrupted, status)
+		t.Fail()
+	}
+}
+
+func TestReplEndsAtEOF(t *testing.T) {
+	cfg := newTestConfig(t)
+	if status := repl(cfg, scanLines(strings.NewReader("where\nfly\n")), &interrupter{}); status != exitOK {
+		t.Errorf("expected the REPL to exit cleanly at EOF, got status %d", status)
+		t.Fail()
+	}
+}
+
+func TestReplLineEditing(t *testing.T) {
+	cfg := newTestConfig(t)
+	// Complete travel, give up on a line with Ctrl-C, then end with Ctrl-D.
+	editor := lineedit.New(strings.NewReader("tra\teterna-forest\rwhere\x03\x04"), io.Discard, nil, func(line string) []string {
+		return complete(cfg, line)
+	})
+	var status int
+	out := captureStdout(t, func() {
+		status = repl(cfg, editor.ReadLine, &interrupter{})
+	})
+	if status != exitOK {
+		t.Errorf("expected Ctrl-D to exit cleanly, got status %d", status)
+		t.Fail()
+	}
+	if here := position(cfg).Location; here != "eterna-forest" {
+		t.Errorf("expected to travel to eterna-forest, but am in %s", here)
+		t.Fail()
+	}
+	if !strings.Contains(out, "use exit to quit") {
+		t.Errorf("expected Ctrl-C to return to the prompt, got %q", out)
+		t.Fail()
+	}
+}
+
+func TestComplete(t *testing.T) {
+	cfg := newTestConfig(t)
+	ctx := context.Background()
+	if got := complete(cfg, "ex"); !slices.Equal(got, []string{"exit", "explore"}) {
+		t.Errorf("expected commands starting with ex, got %v", got)
+		t.Fail()
+	}
+	if got := complete(cfg, "catch --ball "); !slices.Equal(got, []string{"poke-ball"}) {
+		t.Errorf("expected the balls in the bag, got %v", got)
+		t.Fail()
+	}
+	if got := complete(cfg, "pokedex --output t"); !slices.Equal(got, []string{"table", "text"}) {
+		t.Errorf("expected output formats, got %v", got)
+		t.Fail()
+	}
+	if got := complete(cfg, "explore "); len(got) != 0 {
+		t.Errorf("expected no areas before any are fetched, got %v", got)
+		t.Fail()
+	}
+	if _, err := cfg.client.GetLocation(ctx, "canalave-city"); err != nil {
+		t.Fatal(err)
+	}
+	if got := complete(cfg, "explore canal"); !slices.Equal(got, []string{"canalave-city-area"}) {
+		t.Errorf("expected the cached area, got %v", got)
+		t.Fail()
+	}
+	if _, err := cfg.client.GetSpeciesIndex(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := complete(cfg, "sprite pika"); !slices.Contains(got, "pikachu") {
+		t.Errorf("expected species from the index, got %v", got)
+		t.Fail()
+	}
+	catchUntilCaught(t, cfg, "pikachu")
+	if got := complete(cfg, "inspect "); !slices.Equal(got, []string{"pikachu"}) {
+		t.Errorf("expected caught Pokemon, got %v", got)
+		t.Fail()
+	}
+}
+
+func TestExploreJSON(t *testing.T) {
+	cfg := newTestConfig(t)
+	var err error
+	out := captureStdout(t, func() {
+		err = execute(context.Background(), cfg, []string{"explore", "canalave-city-area", "--list", "--json"})
+	})
+	if err != nil {
+		t.Fatalf("error listing canalave-city-area: %v", err)
+	}
+	var area struct {
+		Area       string `json:"area"`
+		Encounters []struct {
+			Pokemon string `json:"pokemon"`
+		} `json:"encounters"`
+	}
+	if err := json.Unmarshal([]byte(out), &area); err != nil {
+		t.Fatalf("explore --list --json printed invalid JSON: %v\n%s", err, out)
+	}
+	if area.Area != "canalave-city-area" || len(area.Encounters) == 0 {
+		t.Errorf("unexpected area: %+v", area)
+		t.Fail()
+	}
+	if cfg.encounter != nil {
+		t.Errorf("explore --list should not start an encounter")
+		t.Fail()
+	}
+
+	// The saved format changes how explore is shown, not what it does.
+	if err := commandFormat(context.Background(), cfg, "json"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50 && cfg.encounter == nil; i++ {
+		out = captureStdout(t, func() {
+			err = execute(context.Background(), cfg, []string{"explore", "canalave-city-area"})
+		})
+		if err != nil {
+			t.Fatalf("error exploring canalave-city-area: %v", err)
+		}
+	}
+	var found struct {
+		Area    string `json:"area"`
+		Pokemon string `json:"pokemon"`
+		Level   int    `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(out), &found); err != nil {
+		t.Fatalf("explore printed invalid JSON: %v\n%s", err, out)
+	}
+	if cfg.encounter == nil || found.Pokemon != cfg.encounter.pokemon.Name || found.Level == 0 {
+		t.Errorf("expected the wild Pokemon in the JSON, got %+v", found)
+		t.Fail()
+	}
+}
+
+func TestOutputFormats(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "pikachu")
+
+	out := captureStdout(t, func() {
+		if err := execute(context.Background(), cfg, []string{"pokedex", "--output", "csv"}); err != nil {
+			t.Errorf("error listing the pokedex as CSV: %v", err)
+			t.Fail()
+		}
+	})
+	if out != "name,caught\npikachu,true\n" {
+		t.Errorf("unexpected pokedex CSV: %q", out)
+		t.Fail()
+	}
+	if cfg.format != "" {
+		t.Errorf("--output should only apply to one command, but the format is still %s", cfg.format)
+		t.Fail()
+	}
+	if err := execute(context.Background(), cfg, []string{"bag", "--json"}); err == nil {
+		t.Errorf("bag --json did not err, though bag only writes text")
+		t.Fail()
+	}
+
+	if err := commandFormat(context.Background(), cfg, "yaml"); err == nil {
+		t.Errorf("choosing an unknown format did not err")
+		t.Fail()
+	}
+	if err := commandFormat(context.Background(), cfg, "json"); err != nil {
+		t.Fatalf("error choosing JSON output: %v", err)
+	}
+	out = captureStdout(t, func() {
+		if err := execute(context.Background(), cfg, []string{"inspect", "pikachu"}); err != nil {
+			t.Errorf("error inspecting pikachu as JSON: %v", err)
+			t.Fail()
+		}
+	})
+	var pikachu struct {
+		Name  string `json:"name"`
+		Stats []struct {
+			Name string `json:"name"`
+		} `json:"stats"`
+	}
+	if err := json.Unmarshal([]byte(out), &pikachu); err != nil {
+		t.Fatalf("inspect printed invalid JSON: %v\n%s", err, out)
+	}
+	if pikachu.Name != "pikachu" || len(pikachu.Stats) != 6 {
+		t.Errorf("unexpected inspect JSON: %+v", pikachu)
+		t.Fail()
+	}
+
+	loaded := newTestConfig(t)
+	if err := loadTrainer(loaded, cfg.savePath); err != nil {
+		t.Fatal(err)
+	}
+	if format := outputFormat(loaded); format != output.JSON {
+		t.Errorf("expected the JSON format to be saved, got %s", format)
+		t.Fail()
+	}
+}
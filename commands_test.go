@@ -0,0 +1,778 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/faust-m/pokedexcli/internal/catch"
+	"github.com/faust-m/pokedexcli/internal/fixture"
+	"github.com/faust-m/pokedexcli/internal/learnset"
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+	"github.com/faust-m/pokedexcli/internal/save"
+	"github.com/faust-m/pokedexcli/internal/stats"
+	"github.com/faust-m/pokedexcli/internal/trainer"
+)
+
+const fixtureDir = "testdata/fixtures"
+
+func newTestConfig(t *testing.T) *config {
+	t.Helper()
+	return &config{
+		savePath: filepath.Join(t.TempDir(), "save.json"),
+		client: pokeapi.NewClient(
+			pokeapi.WithHTTPClient(&http.Client{Transport: fixture.FromFlags(fixtureDir)}),
+			pokeapi.WithRateLimit(0, 0),
+		),
+		catcher: catch.NewEngine(1),
+		rng:     rand.New(rand.NewSource(1)),
+		trainer: trainer.New(),
+	}
+}
+
+func hasCaught(cfg *config, name string) bool {
+	_, _, _, err := cfg.trainer.Find(name)
+	return err == nil
+}
+
+// catchUntilCaught encounters a level 5 wild Pokemon and throws until it is
+// caught.
+func catchUntilCaught(t *testing.T, cfg *config, name string) {
+	t.Helper()
+	if err := startEncounter(context.Background(), cfg, name, 5); err != nil {
+		t.Fatalf("error encountering %s: %v", name, err)
+	}
+	cfg.trainer.AddItem("poke-ball", 100)
+	for i := 0; i < 100; i++ {
+		if err := commandCatch(context.Background(), cfg, name); err != nil {
+			t.Fatalf("error catching %s: %v", name, err)
+		}
+		if cfg.encounter == nil {
+			return
+		}
+	}
+	t.Fatalf("%s was not caught after 100 throws", name)
+}
+
+func TestCommandMapPaging(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandMapb(context.Background(), cfg); err != nil {
+		t.Fatalf("error in mapb: %v", err)
+	}
+	if cfg.mapPage != 0 {
+		t.Errorf("mapb on the first page should not move, got page %d", cfg.mapPage)
+		t.Fail()
+	}
+	for i := 0; i < 3; i++ {
+		if err := commandMap(context.Background(), cfg); err != nil {
+			t.Fatalf("error in map %d: %v", i+1, err)
+		}
+	}
+	// Sinnoh's 26 locations fit on two pages.
+	if cfg.mapPage != 2 {
+		t.Errorf("expected to stop after page 2, got %d", cfg.mapPage)
+		t.Fail()
+	}
+	if err := commandMapb(context.Background(), cfg); err != nil {
+		t.Fatalf("error in mapb: %v", err)
+	}
+	if cfg.mapPage != 1 {
+		t.Errorf("mapb should go back to page 1, got %d", cfg.mapPage)
+		t.Fail()
+	}
+}
+
+func TestCommandExplore(t *testing.T) {
+	cfg := newTestConfig(t)
+	if _, err := commandExplore(context.Background(), cfg, "canalave-city-area"); err != nil {
+		t.Errorf("error exploring: %v", err)
+		t.Fail()
+	}
+	if _, err := commandExplore(context.Background(), cfg); err != nil {
+		t.Errorf("error exploring the only area in canalave-city: %v", err)
+		t.Fail()
+	}
+	if _, err := commandExplore(context.Background(), cfg, "invalid-area"); err == nil {
+		t.Errorf("exploring invalid-area did not err")
+		t.Fail()
+	}
+	if _, err := commandExplore(context.Background(), cfg, "eterna-forest-area"); err == nil {
+		t.Errorf("exploring an area in another location did not err")
+		t.Fail()
+	}
+}
+
+func TestCommandTravel(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandTravel(context.Background(), cfg, "mt-coronet"); err != nil {
+		t.Fatalf("error travelling to mt-coronet: %v", err)
+	}
+	if _, err := commandExplore(context.Background(), cfg); err == nil {
+		t.Errorf("explore without an area in mt-coronet did not err")
+		t.Fail()
+	}
+	if err := commandTravel(context.Background(), cfg, "pallet-town"); err == nil {
+		t.Errorf("travelling to another region's location did not err")
+		t.Fail()
+	}
+	if err := commandTravel(context.Background(), cfg, "kanto"); err != nil {
+		t.Fatalf("error travelling to kanto: %v", err)
+	}
+	want := trainer.Position{Region: "kanto", Location: "pallet-town"}
+	if cfg.trainer.Position != want {
+		t.Errorf("expected to arrive at %+v, got %+v", want, cfg.trainer.Position)
+		t.Fail()
+	}
+	if err := commandWhere(context.Background(), cfg); err != nil {
+		t.Errorf("error in where: %v", err)
+		t.Fail()
+	}
+	if err := commandTravel(context.Background(), cfg, "atlantis"); err == nil {
+		t.Errorf("travelling to atlantis did not err")
+		t.Fail()
+	}
+}
+
+func TestCommandCatchAndInspect(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "magikarp")
+	if _, err := commandInspect(context.Background(), cfg, "magikarp"); err != nil {
+		t.Errorf("error inspecting: %v", err)
+		t.Fail()
+	}
+	if err := commandCatch(context.Background(), cfg); err == nil {
+		t.Errorf("catch without an encounter did not err")
+		t.Fail()
+	}
+	if err := startEncounter(context.Background(), cfg, "pikachu", 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := commandCatch(context.Background(), cfg, "magikarp"); err == nil {
+		t.Errorf("catching a Pokemon that is not the wild encounter did not err")
+		t.Fail()
+	}
+}
+
+func TestCommandSaveLoad(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "magikarp")
+	cfg.trainer = trainer.New()
+	if err := commandLoad(context.Background(), cfg); err != nil {
+		t.Fatalf("error loading autosave: %v", err)
+	}
+	if !hasCaught(cfg, "magikarp") {
+		t.Errorf("magikarp was not autosaved")
+		t.Fail()
+	}
+}
+
+func TestLoadSwitchesAutosave(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "pikachu")
+	original, err := os.ReadFile(cfg.savePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := filepath.Join(t.TempDir(), "other.json")
+	if err := commandSave(context.Background(), cfg, other); err != nil {
+		t.Fatal(err)
+	}
+	defaultPath := cfg.savePath
+	if err := commandLoad(context.Background(), cfg, other); err != nil {
+		t.Fatalf("error loading %s: %v", other, err)
+	}
+	catchUntilCaught(t, cfg, "magikarp")
+	if after, _ := os.ReadFile(defaultPath); !bytes.Equal(after, original) {
+		t.Errorf("autosave after loading another file overwrote the default save")
+		t.Fail()
+	}
+	loaded := newTestConfig(t)
+	if err := loadTrainer(loaded, other); err != nil || !hasCaught(loaded, "magikarp") {
+		t.Errorf("expected magikarp to be autosaved to the loaded file (%v)", err)
+		t.Fail()
+	}
+}
+
+func TestNewerSaveSurvivesSession(t *testing.T) {
+	cfg := newTestConfig(t)
+	path := cfg.savePath
+	cfg.savePath = ""
+	data := []byte(`{"version": 999, "trainer": {"party": [{"id": 1, "name": "eevee", "species": "eevee"}]}}`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := openSave(cfg, path); !errors.Is(err, save.ErrNewerVersion) {
+		t.Errorf("expected ErrNewerVersion, got %v", err)
+		t.Fail()
+	}
+	catchUntilCaught(t, cfg, "magikarp")
+	if status := runScript(context.Background(), cfg, "travel eterna-forest; shop buy poke-ball"); status != exitOK {
+		t.Fatalf("expected the session to go on, got status %d", status)
+	}
+	if after, err := os.ReadFile(path); err != nil || !bytes.Equal(after, data) {
+		t.Errorf("expected the newer save to be left alone, got %s", after)
+		t.Fail()
+	}
+}
+
+func TestCommandCache(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandMap(context.Background(), cfg); err != nil {
+		t.Fatalf("error in map: %v", err)
+	}
+	if cfg.client.Cache().Len() == 0 {
+		t.Errorf("map response was not cached")
+		t.Fail()
+	}
+	if err := commandCache(context.Background(), cfg, "stats"); err != nil {
+		t.Errorf("error in cache stats: %v", err)
+		t.Fail()
+	}
+	if err := commandCache(context.Background(), cfg, "clear"); err != nil {
+		t.Fatalf("error in cache clear: %v", err)
+	}
+	if cfg.client.Cache().Len() != 0 {
+		t.Errorf("cache was not cleared")
+		t.Fail()
+	}
+	if err := commandCache(context.Background(), cfg, "bogus"); err == nil {
+		t.Errorf("unknown cache command did not err")
+		t.Fail()
+	}
+}
+
+func TestCommandEvolve(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandEvolutions(context.Background(), cfg, "eevee"); err != nil {
+		t.Errorf("error showing evolutions: %v", err)
+		t.Fail()
+	}
+	catchUntilCaught(t, cfg, "eevee")
+	if err := commandEvolve(context.Background(), cfg, "eevee"); err == nil {
+		t.Errorf("eevee evolved without a stone")
+		t.Fail()
+	}
+	if err := commandEvolve(context.Background(), cfg, "eevee", "water-stone"); err != nil {
+		t.Fatalf("error evolving eevee: %v", err)
+	}
+	if !hasCaught(cfg, "vaporeon") {
+		t.Errorf("eevee did not become vaporeon after evolving")
+		t.Fail()
+	}
+	if hasCaught(cfg, "eevee") {
+		t.Errorf("eevee still in storage after evolving")
+		t.Fail()
+	}
+	if err := commandEvolve(context.Background(), cfg, "eevee"); err == nil {
+		t.Errorf("evolving a Pokemon that was not caught did not err")
+		t.Fail()
+	}
+}
+
+func TestEvolveByFriendship(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "buneary")
+	buneary, _, _, _ := cfg.trainer.Find("buneary")
+	if buneary.Friendship != 50 {
+		t.Errorf("expected buneary to start at its base happiness of 50, got %d", buneary.Friendship)
+		t.Fail()
+	}
+	if err := commandEvolve(context.Background(), cfg, "buneary"); err == nil {
+		t.Errorf("buneary evolved without enough friendship")
+		t.Fail()
+	}
+	buneary.Befriend(170)
+	if err := commandEvolve(context.Background(), cfg, "buneary"); err != nil {
+		t.Fatalf("error evolving buneary: %v", err)
+	}
+	if !hasCaught(cfg, "lopunny") {
+		t.Errorf("buneary did not become lopunny")
+		t.Fail()
+	}
+}
+
+func TestEvolveByLocation(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "eevee")
+	if err := commandTravel(context.Background(), cfg, "eterna-forest"); err != nil {
+		t.Fatal(err)
+	}
+	if err := commandEvolve(context.Background(), cfg, "eevee"); err != nil {
+		t.Fatalf("error evolving eevee in eterna-forest: %v", err)
+	}
+	if !hasCaught(cfg, "leafeon") {
+		t.Errorf("eevee did not become leafeon in eterna-forest")
+		t.Fail()
+	}
+}
+
+func TestCommandMatchup(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandMatchup(context.Background(), cfg, "pikachu", "gyarados"); err != nil {
+		t.Fatalf("error in matchup: %v", err)
+	}
+	if cfg.types == nil || cfg.types.Effectiveness("electric", "water", "flying") != 4 {
+		t.Errorf("type chart was not loaded")
+		t.Fail()
+	}
+	if err := commandMatchup(context.Background(), cfg, "pikachu"); err == nil {
+		t.Errorf("matchup with one Pokemon did not err")
+		t.Fail()
+	}
+}
+
+func exploreUntilEncounter(t *testing.T, cfg *config, area string, flags ...string) {
+	t.Helper()
+	for i := 0; i < 50; i++ {
+		if _, err := commandExplore(context.Background(), cfg, append([]string{area}, flags...)...); err != nil {
+			t.Fatalf("error exploring %s: %v", area, err)
+		}
+		if cfg.encounter != nil {
+			return
+		}
+	}
+	t.Fatalf("no encounter in %s after 50 tries", area)
+}
+
+func TestExploreMethod(t *testing.T) {
+	cfg := newTestConfig(t)
+	for i := 0; i < 5; i++ {
+		cfg.encounter = nil
+		exploreUntilEncounter(t, cfg, "canalave-city-area", "--method", "old-rod")
+		wild := cfg.encounter.wild
+		if wild.Name != "magikarp" || wild.Level < 5 || wild.Level > 15 {
+			t.Errorf("expected a level 5-15 magikarp from the old rod, got %s at level %d", wild.Name, wild.Level)
+			t.Fail()
+		}
+	}
+	if _, err := commandExplore(context.Background(), cfg, "canalave-city-area", "--method", "walk"); err == nil {
+		t.Errorf("walking in an area with only water encounters did not err")
+		t.Fail()
+	}
+	if _, err := commandExplore(context.Background(), cfg, "--method"); err == nil {
+		t.Errorf("--method without a value did not err")
+		t.Fail()
+	}
+}
+
+func TestWildBattle(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "pikachu")
+	exploreUntilEncounter(t, cfg, "canalave-city-area")
+	if err := commandAttack(context.Background(), cfg, "thunder-shock"); err == nil {
+		t.Errorf("attack before fight did not err")
+		t.Fail()
+	}
+	if err := commandFight(context.Background(), cfg, "pikachu"); err != nil {
+		t.Fatalf("error starting fight: %v", err)
+	}
+	fight := cfg.encounter.fight
+	move := fight.Player.Moves[0].Name
+	for i := 0; i < 100 && cfg.encounter != nil; i++ {
+		if err := commandAttack(context.Background(), cfg, move); err != nil {
+			t.Fatalf("error attacking: %v", err)
+		}
+	}
+	if cfg.encounter != nil {
+		t.Errorf("battle did not end")
+		t.Fail()
+	}
+	if !fight.Over() {
+		t.Errorf("encounter ended before the battle was over")
+		t.Fail()
+	}
+}
+
+func TestFighterStaysInParty(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "pikachu")
+	catchUntilCaught(t, cfg, "magikarp")
+	exploreUntilEncounter(t, cfg, "canalave-city-area")
+	if err := commandFight(context.Background(), cfg, "pikachu"); err != nil {
+		t.Fatalf("error starting fight: %v", err)
+	}
+	if err := commandRelease(context.Background(), cfg, "pikachu"); err == nil {
+		t.Errorf("releasing the Pokemon in battle did not err")
+		t.Fail()
+	}
+	if err := commandDeposit(context.Background(), cfg, "pikachu"); err == nil {
+		t.Errorf("depositing the Pokemon in battle did not err")
+		t.Fail()
+	}
+	if err := commandDeposit(context.Background(), cfg, "magikarp"); err != nil {
+		t.Errorf("error depositing a Pokemon not in battle: %v", err)
+		t.Fail()
+	}
+	if err := commandLoad(context.Background(), cfg); err != nil {
+		t.Fatalf("error loading: %v", err)
+	}
+	if cfg.encounter != nil {
+		t.Errorf("loading a save should end the encounter")
+		t.Fail()
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "pikachu")
+	pikachu, _, _, _ := cfg.trainer.Find("pikachu")
+	if pikachu.Level != 5 {
+		t.Fatalf("expected level 5, got %d", pikachu.Level)
+	}
+	gyarados, err := cfg.client.GetPokemonData(context.Background(), "gyarados")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gainExperience(context.Background(), cfg, pikachu, gyarados, 30); err != nil {
+		t.Fatalf("error gaining experience: %v", err)
+	}
+	if pikachu.Level <= 5 {
+		t.Errorf("expected pikachu to level up, got level %d with %d exp", pikachu.Level, pikachu.Experience)
+		t.Fail()
+	}
+	if pikachu.EVs.Attack == 0 {
+		t.Errorf("expected attack EVs from gyarados, got %+v", pikachu.EVs)
+		t.Fail()
+	}
+	if want := 50 + friendshipPerWin + friendshipPerLevel*(pikachu.Level-5); pikachu.Friendship != want {
+		t.Errorf("expected friendship %d after winning and levelling up, got %d", want, pikachu.Friendship)
+		t.Fail()
+	}
+}
+
+func TestCatchWildEncounter(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandTravel(context.Background(), cfg, "eterna-forest"); err != nil {
+		t.Fatalf("error travelling to eterna-forest: %v", err)
+	}
+	exploreUntilEncounter(t, cfg, "eterna-forest-area")
+	name := cfg.encounter.pokemon.Name
+	level := cfg.encounter.wild.Level
+	cfg.trainer.AddItem("poke-ball", 100)
+	for i := 0; i < 100 && cfg.encounter != nil; i++ {
+		if err := commandCatch(context.Background(), cfg); err != nil {
+			t.Fatalf("error catching: %v", err)
+		}
+	}
+	if !hasCaught(cfg, name) {
+		t.Errorf("wild %s was not caught", name)
+		t.Fail()
+	}
+	if caught, _, _, _ := cfg.trainer.Find(name); caught != nil && caught.Level != level {
+		t.Errorf("expected caught %s at level %d, got %d", name, level, caught.Level)
+		t.Fail()
+	}
+	if err := commandRun(context.Background(), cfg); err == nil {
+		t.Errorf("run without an encounter did not err")
+		t.Fail()
+	}
+}
+
+func TestCommandStorage(t *testing.T) {
+	cfg := newTestConfig(t)
+	for i := 0; i < trainer.PartySize+1; i++ {
+		catchUntilCaught(t, cfg, "magikarp")
+	}
+	if len(cfg.trainer.Party) != trainer.PartySize || len(cfg.trainer.Boxes[0]) != 1 {
+		t.Fatalf("seventh magikarp should be boxed, party has %d", len(cfg.trainer.Party))
+	}
+	if err := commandWithdraw(context.Background(), cfg, "#7"); err == nil {
+		t.Errorf("withdraw into a full party did not err")
+		t.Fail()
+	}
+	if err := commandDeposit(context.Background(), cfg, "#1"); err != nil {
+		t.Fatalf("error depositing: %v", err)
+	}
+	if err := commandWithdraw(context.Background(), cfg, "#7"); err != nil {
+		t.Fatalf("error withdrawing: %v", err)
+	}
+	if err := commandSwap(context.Background(), cfg, "#1", "#2"); err != nil {
+		t.Fatalf("error swapping: %v", err)
+	}
+	if cfg.trainer.Party[0].ID != 1 {
+		t.Errorf("swap did not move #1 into the party")
+		t.Fail()
+	}
+	if err := commandRelease(context.Background(), cfg, "#2"); err != nil {
+		t.Fatalf("error releasing: %v", err)
+	}
+	if len(cfg.trainer.All()) != trainer.PartySize {
+		t.Errorf("release did not remove #2")
+		t.Fail()
+	}
+	for _, cmd := range []func(context.Context, *config, ...string) error{commandParty, commandBox} {
+		if err := cmd(context.Background(), cfg); err != nil {
+			t.Errorf("error listing: %v", err)
+			t.Fail()
+		}
+	}
+	if err := render(context.Background(), cfg, commandPokedex); err != nil {
+		t.Errorf("error listing the pokedex: %v", err)
+		t.Fail()
+	}
+}
+
+func TestCommandVersion(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandVersions(context.Background(), cfg); err != nil {
+		t.Errorf("error listing versions: %v", err)
+		t.Fail()
+	}
+	if err := commandVersion(context.Background(), cfg, "atlantis"); err == nil {
+		t.Errorf("choosing version atlantis did not err")
+		t.Fail()
+	}
+	if err := commandVersion(context.Background(), cfg, "red"); err != nil {
+		t.Fatalf("error choosing red: %v", err)
+	}
+	if cfg.settings.VersionGroup != "red-blue" || cfg.settings.Generation != "generation-i" {
+		t.Errorf("unexpected settings for red: %+v", cfg.settings)
+		t.Fail()
+	}
+	// Sinnoh has no encounters in red.
+	for i := 0; i < 10; i++ {
+		if _, err := commandExplore(context.Background(), cfg, "canalave-city-area"); err != nil {
+			t.Fatalf("error exploring: %v", err)
+		}
+		if cfg.encounter != nil {
+			t.Fatalf("encountered %s in red", cfg.encounter.pokemon.Name)
+		}
+	}
+	// Pikachu learns tail-whip at level 8 in red and level 5 in diamond.
+	pikachu, err := cfg.client.GetPokemonData(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for version, moves := range map[string]int{"red": 2, "diamond": 3} {
+		if err := commandVersion(context.Background(), cfg, version); err != nil {
+			t.Fatalf("error choosing %s: %v", version, err)
+		}
+		known := learnset.Default(learnset.For(pikachu, versionGroup(cfg, pikachu)), 5)
+		if len(known) != moves {
+			t.Errorf("expected %d moves in %s, got %v", moves, version, known)
+			t.Fail()
+		}
+	}
+
+	cfg.trainer = trainer.New()
+	if err := commandLoad(context.Background(), cfg); err != nil {
+		t.Fatalf("error loading autosave: %v", err)
+	}
+	if cfg.settings.GameVersion == "" {
+		t.Errorf("game version was not saved")
+		t.Fail()
+	}
+}
+
+func TestCommandShop(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandShop(context.Background(), cfg); err != nil {
+		t.Fatalf("error listing the shop: %v", err)
+	}
+	if err := commandShop(context.Background(), cfg, "buy", "great-ball", "3"); err != nil {
+		t.Fatalf("error buying great balls: %v", err)
+	}
+	if cfg.trainer.Bag["great-ball"] != 3 || cfg.trainer.Money != trainer.StartingMoney-3*600 {
+		t.Errorf("unexpected bag and money after buying: %v, %d", cfg.trainer.Bag, cfg.trainer.Money)
+		t.Fail()
+	}
+	if err := commandShop(context.Background(), cfg, "buy", "ultra-ball", "10"); !errors.Is(err, trainer.ErrNotEnoughMoney) {
+		t.Errorf("expected ErrNotEnoughMoney, got %v", err)
+		t.Fail()
+	}
+	money := cfg.trainer.Money
+	if err := commandShop(context.Background(), cfg, "buy", "poke-ball", "46116860184273880"); !errors.Is(err, trainer.ErrNotEnoughMoney) {
+		t.Errorf("expected a huge order to be ErrNotEnoughMoney, got %v", err)
+		t.Fail()
+	}
+	if cfg.trainer.Money != money || cfg.trainer.Bag["great-ball"] != 3 {
+		t.Errorf("a failed order changed the bag or money: %v, %d", cfg.trainer.Bag, cfg.trainer.Money)
+		t.Fail()
+	}
+	if err := commandShop(context.Background(), cfg, "buy", "potion"); err == nil {
+		t.Errorf("buying a potion did not err")
+		t.Fail()
+	}
+	if err := commandShop(context.Background(), cfg, "buy", "master-ball"); err == nil {
+		t.Errorf("buying a master-ball did not err")
+		t.Fail()
+	}
+	if err := commandShop(context.Background(), cfg, "sell", "great-ball"); err != nil {
+		t.Fatalf("error selling a great ball: %v", err)
+	}
+	if cfg.trainer.Bag["great-ball"] != 2 || cfg.trainer.Money != trainer.StartingMoney-3*600+300 {
+		t.Errorf("unexpected bag and money after selling: %v, %d", cfg.trainer.Bag, cfg.trainer.Money)
+		t.Fail()
+	}
+	if err := commandShop(context.Background(), cfg, "sell", "ultra-ball"); err == nil {
+		t.Errorf("selling an item not in the bag did not err")
+		t.Fail()
+	}
+	if err := commandBag(context.Background(), cfg); err != nil {
+		t.Errorf("error listing the bag: %v", err)
+		t.Fail()
+	}
+}
+
+func TestCatchWithBall(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := startEncounter(context.Background(), cfg, "gyarados", 30); err != nil {
+		t.Fatal(err)
+	}
+	if err := commandCatch(context.Background(), cfg, "--ball", "great-ball"); err == nil {
+		t.Errorf("throwing a great ball without any did not err")
+		t.Fail()
+	}
+	if err := commandCatch(context.Background(), cfg, "--ball", "potion"); err == nil {
+		t.Errorf("throwing a potion did not err")
+		t.Fail()
+	}
+	cfg.trainer.AddItem("master-ball", 1)
+	if err := commandCatch(context.Background(), cfg, "gyarados", "--ball", "master-ball"); err != nil {
+		t.Fatalf("error throwing a master ball: %v", err)
+	}
+	if !hasCaught(cfg, "gyarados") || cfg.trainer.Bag["master-ball"] != 0 {
+		t.Errorf("master ball should catch and be used up, bag: %v", cfg.trainer.Bag)
+		t.Fail()
+	}
+}
+
+func TestCommandMoves(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandMoves(context.Background(), cfg, "pikachu"); err != nil {
+		t.Errorf("error listing pikachu's moves: %v", err)
+		t.Fail()
+	}
+	if err := commandMove(context.Background(), cfg, "thunderbolt"); err != nil {
+		t.Errorf("error showing thunderbolt: %v", err)
+		t.Fail()
+	}
+	if err := commandMove(context.Background(), cfg, "atlantis"); err == nil {
+		t.Errorf("showing move atlantis did not err")
+		t.Fail()
+	}
+}
+
+func TestLearnAndForget(t *testing.T) {
+	cfg := newTestConfig(t)
+	catchUntilCaught(t, cfg, "pikachu")
+	pikachu, _, _, _ := cfg.trainer.Find("pikachu")
+	if len(pikachu.Moves) != 3 {
+		t.Fatalf("expected a level 5 pikachu to know 3 moves, got %v", pikachu.Moves)
+	}
+	if err := commandLearn(context.Background(), cfg, "pikachu", "quick-attack"); err == nil {
+		t.Errorf("learning quick-attack below level 13 did not err")
+		t.Fail()
+	}
+	if err := commandForget(context.Background(), cfg, "pikachu", "growl"); err != nil {
+		t.Fatalf("error forgetting growl: %v", err)
+	}
+	if err := commandLearn(context.Background(), cfg, "pikachu", "growl"); err != nil {
+		t.Fatalf("error relearning growl: %v", err)
+	}
+
+	// Levelling up to 13 teaches thunder-wave, filling the fourth slot,
+	// and then quick-attack has to replace a move.
+	gyarados, err := cfg.client.GetPokemonData(context.Background(), "gyarados")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for pikachu.Level < 13 {
+		if err := gainExperience(context.Background(), cfg, pikachu, gyarados, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(pikachu.Moves) != 4 || !slices.Contains(pikachu.Moves, "thunder-wave") {
+		t.Fatalf("expected thunder-wave to be learned on level up, got %v", pikachu.Moves)
+	}
+	if err := commandLearn(context.Background(), cfg, "pikachu", "quick-attack"); err == nil {
+		t.Errorf("learning a fifth move did not err")
+		t.Fail()
+	}
+	if err := commandLearn(context.Background(), cfg, "pikachu", "quick-attack", "tail-whip"); err != nil {
+		t.Fatalf("error replacing tail-whip: %v", err)
+	}
+	if slices.Contains(pikachu.Moves, "tail-whip") || !slices.Contains(pikachu.Moves, "quick-attack") {
+		t.Errorf("expected quick-attack to replace tail-whip, got %v", pikachu.Moves)
+		t.Fail()
+	}
+}
+
+func TestCommandAbility(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := commandAbility(context.Background(), cfg, "static"); err != nil {
+		t.Errorf("error showing static: %v", err)
+		t.Fail()
+	}
+	if err := commandAbility(context.Background(), cfg, "atlantis"); err == nil {
+		t.Errorf("showing ability atlantis did not err")
+		t.Fail()
+	}
+
+	// Pikachu's only regular ability is static; lightning-rod is hidden.
+	catchUntilCaught(t, cfg, "pikachu")
+	pikachu, _, _, _ := cfg.trainer.Find("pikachu")
+	if pikachu.Ability != "static" {
+		t.Errorf("expected a caught pikachu to have static, got %q", pikachu.Ability)
+		t.Fail()
+	}
+	if _, err := commandInspect(context.Background(), cfg, "pikachu"); err != nil {
+		t.Errorf("error inspecting pikachu: %v", err)
+		t.Fail()
+	}
+}
+
+func TestCommandNature(t *testing.T) {
+	cfg := newTestConfig(t)
+	for _, n := range stats.Natures {
+		if err := commandNature(context.Background(), cfg, n.Name); err != nil {
+			t.Fatalf("error showing %s: %v", n.Name, err)
+		}
+		nature, err := cfg.client.GetNature(context.Background(), n.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var increased, decreased string
+		if nature.IncreasedStat != nil {
+			increased, decreased = nature.IncreasedStat.Name, nature.DecreasedStat.Name
+		}
+		if increased != n.Increased || decreased != n.Decreased {
+			t.Errorf("%s raises %q and lowers %q, PokeAPI says %q and %q", n.Name, n.Increased, n.Decreased, increased, decreased)
+			t.Fail()
+		}
+	}
+	if err := commandNature(context.Background(), cfg, "atlantis"); err == nil {
+		t.Errorf("showing nature atlantis did not err")
+		t.Fail()
+	}
+}
+
+func TestCommandSprite(t *testing.T) {
+	cfg := newTestConfig(t)
+	for _, args := range [][]string{{"pikachu"}, {"pikachu", "shiny"}, {"pikachu", "back"}} {
+		if err := commandSprite(context.Background(), cfg, args...); err != nil {
+			t.Errorf("error drawing sprite %v: %v", args, err)
+			t.Fail()
+		}
+	}
+	if err := commandSprite(context.Background(), cfg, "pikachu", "shiny", "back"); err == nil {
+		t.Errorf("drawing a missing back shiny sprite did not err")
+		t.Fail()
+	}
+	if err := commandSprite(context.Background(), cfg, "pikachu", "sideways"); err == nil {
+		t.Errorf("drawing a sprite sideways did not err")
+		t.Fail()
+	}
+
+	catchUntilCaught(t, cfg, "pikachu")
+	if _, err := commandInspect(context.Background(), cfg, "pikachu", "--sprite"); err != nil {
+		t.Errorf("error inspecting pikachu with its sprite: %v", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/faust-m/pokedexcli/internal/catch"
+	"github.com/faust-m/pokedexcli/internal/output"
+)
+
+// caughtCommands take the name of a caught Pokemon as an argument.
+var caughtCommands = []string{
+	"inspect", "deposit", "withdraw", "swap", "release",
+	"moves", "learn", "forget", "evolve",
+}
+
+// speciesCommands take the name of any Pokemon as an argument.
+var speciesCommands = []string{"sprite", "evolutions", "matchup"}
+
+// complete returns the completions of the last word of line for the REPL's
+// tab key. It only looks at the save and cached responses, so it never waits
+// on the network.
+func complete(cfg *config, line string) []string {
+	words := strings.Fields(strings.ToLower(line))
+	if len(words) == 0 || strings.HasSuffix(line, " ") {
+		words = append(words, "")
+	}
+	word := words[len(words)-1]
+	if len(words) == 1 {
+		return matching(word, slices.Collect(maps.Keys(cmds)))
+	}
+	switch previous := words[len(words)-2]; {
+	case previous == "--ball":
+		var balls []string
+		for name := range catch.Balls {
+			if cfg.trainer.Bag[name] > 0 {
+				balls = append(balls, name)
+			}
+		}
+		return matching(word, balls)
+	case previous == "--output":
+		if cmds[words[0]].result == nil {
+			return nil
+		}
+		var formats []string
+		for _, f := range output.Formats {
+			formats = append(formats, string(f))
+		}
+		return matching(word, formats)
+	case strings.HasPrefix(word, "-"):
+		return nil
+	}
+	switch command := words[0]; {
+	case command == "explore":
+		return matching(word, cfg.client.CachedAreaNames())
+	case command == "catch":
+		if cfg.encounter != nil {
+			return matching(word, []string{cfg.encounter.pokemon.Name})
+		}
+		return nil
+	case slices.Contains(caughtCommands, command):
+		var names []string
+		for _, p := range cfg.trainer.All() {
+			names = append(names, p.Name)
+		}
+		return matching(word, names)
+	case slices.Contains(speciesCommands, command):
+		names := slices.Collect(maps.Keys(cfg.trainer.Pokedex.Seen))
+		return matching(word, append(names, cfg.client.CachedSpeciesNames()...))
+	}
+	return nil
+}
+
+// matching returns the sorted, distinct candidates starting with prefix.
+func matching(prefix string, candidates []string) []string {
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) {
+			matches = append(matches, c)
+		}
+	}
+	slices.Sort(matches)
+	return slices.Compact(matches)
+}
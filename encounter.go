@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/faust-m/pokedexcli/internal/battle"
+	"github.com/faust-m/pokedexcli/internal/encounters"
+	"github.com/faust-m/pokedexcli/internal/learnset"
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+	"github.com/faust-m/pokedexcli/internal/stats"
+	"github.com/faust-m/pokedexcli/internal/trainer"
+)
+
+const (
+	// wildEncounterRate is the chance that exploring finds a wild Pokemon.
+	wildEncounterRate = 0.5
+	// prizePerLevel is the money found after defeating a wild Pokemon, per
+	// level of the Pokemon.
+	prizePerLevel = 10
+	// friendshipPerWin and friendshipPerLevel are the friendship a Pokemon
+	// gains for defeating a wild Pokemon and for each level it grows.
+	friendshipPerWin   = 2
+	friendshipPerLevel = 5
+)
+
+// encounter is the wild Pokemon the player is currently facing, with the
+// IVs, nature and ability it keeps if caught. Once the player sends out a
+// Pokemon, fight holds the battle and fighter the caught Pokemon in it;
+// both are nil until then.
+type encounter struct {
+	pokemon pokeapi.Pokemon
+	ivs     stats.Stats
+	nature  stats.Nature
+	ability string
+	wild    *battle.Combatant
+	fight   *battle.Battle
+	fighter *trainer.Pokemon
+}
+
+// rollEncounter may start a wild encounter from an explored area's
+// encounter table for method, defaulting to walking where possible. Without
+// a chosen game version, the first version with encounters is used.
+func rollEncounter(ctx context.Context, cfg *config, area pokeapi.ExploreResult, method string) (exploreResult, error) {
+	result := exploreResult{Area: area.Name, Location: area.Location.Name}
+	slots := encounters.Slots(area)
+	versions := encounters.Versions(slots)
+	version := cfg.settings.GameVersion
+	if version == "" && len(versions) > 0 {
+		version = versions[0]
+	}
+	if !slices.Contains(versions, version) {
+		return result, nil
+	}
+	methods := encounters.Methods(slots, version)
+	switch {
+	case method == "" && slices.Contains(methods, encounters.Walk):
+		method = encounters.Walk
+	case method == "":
+		method = methods[0]
+	case !slices.Contains(methods, method):
+		return result, fmt.Errorf("no Pokemon can be found by %s here, try %s", method, strings.Join(methods, ", "))
+	}
+	result.Version, result.Method = version, method
+	slot, level, ok := encounters.Roll(cfg.rng, encounters.Filter(slots, version, method))
+	if !ok || cfg.rng.Float64() >= wildEncounterRate {
+		return result, nil
+	}
+	if err := startEncounter(ctx, cfg, slot.Pokemon, level); err != nil {
+		return result, err
+	}
+	result.Pokemon, result.Level = cfg.encounter.pokemon.Name, level
+	return result, nil
+}
+
+// newAreaResult lists an area's encounter table, limited to the chosen game
+// version if there is one.
+func newAreaResult(cfg *config, area pokeapi.ExploreResult) areaResult {
+	slots := append([]encounters.Slot{}, encounters.Slots(area)...)
+	if version := cfg.settings.GameVersion; version != "" {
+		slots = slices.DeleteFunc(slots, func(s encounters.Slot) bool {
+			return s.Version != version
+		})
+	}
+	return areaResult{Area: area.Name, Location: area.Location.Name, Encounters: slots}
+}
+
+// startEncounter puts a wild Pokemon with random IVs, nature and ability in
+// front of the player.
+func startEncounter(ctx context.Context, cfg *config, name string, level int) error {
+	data, err := cfg.client.GetPokemonData(ctx, name)
+	if err != nil {
+		return fmt.Errorf("error getting Pokemon data: %w", err)
+	}
+	ivs, nature := stats.RandomIVs(cfg.rng), stats.RandomNature(cfg.rng)
+	moves := learnset.Default(learnset.For(data, versionGroup(cfg, data)), level)
+	wild, err := newCombatant(ctx, cfg, data, level, stats.Calc(stats.Base(data), ivs, stats.Stats{}, level, nature), moves)
+	if err != nil {
+		return err
+	}
+	cfg.encounter = &encounter{pokemon: data, ivs: ivs, nature: nature, ability: rollAbility(cfg.rng, data), wild: wild}
+	cfg.trainer.See(data.Name)
+	return nil
+}
+
+// newCombatant builds a combatant that knows the named moves.
+func newCombatant(ctx context.Context, cfg *config, data pokeapi.Pokemon, level int, s stats.Stats, moveNames []string) (*battle.Combatant, error) {
+	var moves []battle.Move
+	for _, name := range moveNames {
+		move, err := cfg.client.GetMove(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("error getting move %s: %w", name, err)
+		}
+		moves = append(moves, battle.NewMove(move))
+	}
+	return battle.NewCombatant(data, level, s, moves), nil
+}
+
+func commandFight(ctx context.Context, cfg *config, args ...string) error {
+	if cfg.encounter == nil {
+		return fmt.Errorf("there is no wild Pokemon to fight")
+	}
+	if cfg.encounter.fight != nil {
+		return fmt.Errorf("already fighting %s", cfg.encounter.wild.Name)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("no Pokemon specified to fight with")
+	}
+	caught, err := cfg.trainer.FindInParty(args[0])
+	if err != nil {
+		return err
+	}
+	data, err := cfg.client.GetPokemonData(ctx, caught.Name)
+	if err != nil {
+		return fmt.Errorf("error getting Pokemon data: %w", err)
+	}
+	if len(caught.Moves) == 0 {
+		// Pokemon caught before moves were tracked know their default moves.
+		caught.Moves = learnset.Default(learnset.For(data, versionGroup(cfg, data)), caught.Level)
+	}
+	player, err := newCombatant(ctx, cfg, data, caught.Level, caught.Stats(stats.Base(data)), caught.Moves)
+	if err != nil {
+		return err
+	}
+	chart, err := typeChart(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	cfg.encounter.fight = battle.New(player, cfg.encounter.wild, chart, cfg.rng)
+	cfg.encounter.fighter = caught
+	fmt.Printf("Go, %s! (Lv. %d)\n", player.Name, player.Level)
+	printMoves(player)
+	return nil
+}
+
+func commandAttack(ctx context.Context, cfg *config, args ...string) error {
+	if cfg.encounter == nil || cfg.encounter.fight == nil {
+		return fmt.Errorf("you are not in a battle")
+	}
+	if len(args) == 0 {
+		printMoves(cfg.encounter.fight.Player)
+		return nil
+	}
+	fight := cfg.encounter.fight
+	events, err := fight.Turn(args[0])
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		fmt.Println(e)
+	}
+	switch {
+	case fight.Wild.Fainted():
+		fmt.Printf("The wild %s was defeated!\n", fight.Wild.Name)
+		defeated := cfg.encounter
+		cfg.encounter = nil
+		prize := prizePerLevel * defeated.wild.Level
+		cfg.trainer.Earn(prize)
+		fmt.Printf("You picked up %s.\n", formatMoney(prize))
+		return gainExperience(ctx, cfg, defeated.fighter, defeated.pokemon, defeated.wild.Level)
+	case fight.Player.Fainted():
+		fmt.Printf("The wild %s got away.\n", fight.Wild.Name)
+		cfg.encounter = nil
+	default:
+		fmt.Printf("%s: %d/%d HP, wild %s: %d/%d HP\n",
+			fight.Player.Name, fight.Player.HP, fight.Player.Stats.HP,
+			fight.Wild.Name, fight.Wild.HP, fight.Wild.Stats.HP)
+	}
+	return nil
+}
+
+func commandRun(_ context.Context, cfg *config, _ ...string) error {
+	if cfg.encounter == nil {
+		return fmt.Errorf("there is nothing to run from")
+	}
+	fmt.Println("Got away safely!")
+	cfg.encounter = nil
+	return nil
+}
+
+func printMoves(c *battle.Combatant) {
+	fmt.Println("Moves:")
+	for _, m := range c.Moves {
+		fmt.Printf(" - %s (%s, power %d, PP %d/%d)\n", m.Name, m.Type, m.Power, m.PP, m.MaxPP)
+	}
+}
+
+// gainExperience rewards a caught Pokemon for defeating a wild one with
+// experience, EVs and friendship, levelling it up along its species' growth
+// rate.
+func gainExperience(ctx context.Context, cfg *config, p *trainer.Pokemon, defeated pokeapi.Pokemon, level int) error {
+	rate, err := growthRate(ctx, cfg, p.Species)
+	if err != nil {
+		return err
+	}
+	gained := stats.ExpYield(defeated.BaseExperience, level)
+	p.Experience = max(p.Experience, stats.Experience(rate, p.Level)) + gained
+	p.EVs = stats.AddEVs(p.EVs, stats.Effort(defeated))
+	p.Befriend(friendshipPerWin)
+	fmt.Printf("%s gained %d Exp. Points!\n", p.Name, gained)
+	if newLevel := stats.Level(rate, p.Experience); newLevel > p.Level {
+		fmt.Printf("%s grew to level %d!\n", p.Name, newLevel)
+		data, err := cfg.client.GetPokemonData(ctx, p.Name)
+		if err != nil {
+			return fmt.Errorf("error getting Pokemon data: %w", err)
+		}
+		for _, move := range learnset.Between(learnset.For(data, versionGroup(cfg, data)), p.Level, newLevel) {
+			teachMove(p, move)
+		}
+		p.Befriend(friendshipPerLevel * (newLevel - p.Level))
+		p.Level = newLevel
+	}
+	return autosave(cfg)
+}
+
+func growthRate(ctx context.Context, cfg *config, species string) (pokeapi.GrowthRate, error) {
+	data, err := cfg.client.GetPokemonSpecies(ctx, species)
+	if err != nil {
+		return pokeapi.GrowthRate{}, fmt.Errorf("error getting species data: %w", err)
+	}
+	rate, err := cfg.client.GetGrowthRate(ctx, data.GrowthRate.Name)
+	if err != nil {
+		return pokeapi.GrowthRate{}, fmt.Errorf("error getting growth rate: %w", err)
+	}
+	return rate, nil
+}
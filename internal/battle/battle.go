@@ -0,0 +1,274 @@
+package battle
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+	"github.com/faust-m/pokedexcli/internal/stats"
+)
+
+const (
+	criticalChance     = 24
+	criticalMultiplier = 1.5
+	stabMultiplier     = 1.5
+)
+
+// Effectiveness is satisfied by *typechart.Chart.
+type Effectiveness interface {
+	Effectiveness(attack string, defenders ...string) float64
+}
+
+type Move struct {
+	Name        string
+	Type        string
+	DamageClass string
+	Power       int
+	Accuracy    int
+	Priority    int
+	PP          int
+	MaxPP       int
+}
+
+// Struggle is used when a combatant has no PP left in any move. It has no
+// type and never misses.
+var Struggle = Move{Name: "struggle", DamageClass: "physical", Power: 50}
+
+// NewMove converts an API move. A zero Accuracy never misses and a zero
+// Power deals no damage.
+func NewMove(m pokeapi.Move) Move {
+	move := Move{
+		Name:        m.Name,
+		Type:        m.Type.Name,
+		DamageClass: m.DamageClass.Name,
+		Priority:    m.Priority,
+	}
+	if m.Power != nil {
+		move.Power = *m.Power
+	}
+	if m.Accuracy != nil {
+		move.Accuracy = *m.Accuracy
+	}
+	if m.PP != nil {
+		move.PP = *m.PP
+		move.MaxPP = *m.PP
+	}
+	return move
+}
+
+type Combatant struct {
+	Name  string
+	Level int
+	Types []string
+	Stats stats.Stats
+	HP    int
+	Moves []Move
+}
+
+// NewCombatant enters a Pokemon into battle at full HP with already
+// computed stats.
+func NewCombatant(p pokeapi.Pokemon, level int, s stats.Stats, moves []Move) *Combatant {
+	return &Combatant{
+		Name:  p.Name,
+		Level: level,
+		Types: p.TypeNames(),
+		Stats: s,
+		HP:    s.HP,
+		Moves: moves,
+	}
+}
+
+func (c *Combatant) Fainted() bool {
+	return c.HP <= 0
+}
+
+func (c *Combatant) move(name string) (*Move, bool) {
+	for i := range c.Moves {
+		if c.Moves[i].Name == name {
+			return &c.Moves[i], true
+		}
+	}
+	return nil, false
+}
+
+func (c *Combatant) hasPP() bool {
+	return slices.ContainsFunc(c.Moves, func(m Move) bool { return m.PP > 0 })
+}
+
+type Event struct {
+	Attacker      string
+	Defender      string
+	Move          string
+	Damage        int
+	Effectiveness float64
+	Missed        bool
+	Critical      bool
+	NoDamage      bool
+	Fainted       bool
+}
+
+func (e Event) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s used %s!", e.Attacker, e.Move)
+	switch {
+	case e.Missed:
+		b.WriteString(" It missed!")
+		return b.String()
+	case e.NoDamage:
+		b.WriteString(" But nothing happened.")
+		return b.String()
+	case e.Effectiveness == 0:
+		fmt.Fprintf(&b, " It doesn't affect %s...", e.Defender)
+		return b.String()
+	}
+	if e.Critical {
+		b.WriteString(" A critical hit!")
+	}
+	if e.Effectiveness > 1 {
+		b.WriteString(" It's super effective!")
+	} else if e.Effectiveness < 1 {
+		b.WriteString(" It's not very effective...")
+	}
+	fmt.Fprintf(&b, " %s took %d damage.", e.Defender, e.Damage)
+	if e.Fainted {
+		fmt.Fprintf(&b, " %s fainted!", e.Defender)
+	}
+	return b.String()
+}
+
+type Battle struct {
+	Player *Combatant
+	Wild   *Combatant
+	chart  Effectiveness
+	rng    *rand.Rand
+}
+
+func New(player, wild *Combatant, chart Effectiveness, rng *rand.Rand) *Battle {
+	return &Battle{
+		Player: player,
+		Wild:   wild,
+		chart:  chart,
+		rng:    rng,
+	}
+}
+
+func (b *Battle) Over() bool {
+	return b.Player.Fainted() || b.Wild.Fainted()
+}
+
+// Turn plays one round: the player uses moveName, the wild Pokemon picks a
+// random move, and they act in priority then speed order. With no PP left in
+// any move, the player struggles regardless of moveName.
+func (b *Battle) Turn(moveName string) ([]Event, error) {
+	if b.Over() {
+		return nil, fmt.Errorf("the battle is over")
+	}
+	playerMove := &Struggle
+	if b.Player.hasPP() {
+		move, ok := b.Player.move(moveName)
+		if !ok {
+			return nil, fmt.Errorf("%s does not know %s", b.Player.Name, moveName)
+		}
+		if move.PP <= 0 {
+			return nil, fmt.Errorf("%s has no PP left", move.Name)
+		}
+		playerMove = move
+	}
+	wildMove := b.chooseMove(b.Wild)
+
+	type action struct {
+		attacker, defender *Combatant
+		move               *Move
+	}
+	actions := []action{
+		{b.Player, b.Wild, playerMove},
+		{b.Wild, b.Player, wildMove},
+	}
+	if b.goesSecond(actions[0].attacker, actions[0].move, actions[1].attacker, actions[1].move) {
+		actions[0], actions[1] = actions[1], actions[0]
+	}
+	var events []Event
+	for _, a := range actions {
+		if a.attacker.Fainted() {
+			break
+		}
+		events = append(events, b.attack(a.attacker, a.defender, a.move))
+	}
+	return events, nil
+}
+
+func (b *Battle) chooseMove(c *Combatant) *Move {
+	var usable []int
+	for i, m := range c.Moves {
+		if m.PP > 0 {
+			usable = append(usable, i)
+		}
+	}
+	if len(usable) == 0 {
+		return &Struggle
+	}
+	return &c.Moves[usable[b.rng.Intn(len(usable))]]
+}
+
+func (b *Battle) goesSecond(first *Combatant, firstMove *Move, second *Combatant, secondMove *Move) bool {
+	if firstMove.Priority != secondMove.Priority {
+		return firstMove.Priority < secondMove.Priority
+	}
+	if first.Stats.Speed != second.Stats.Speed {
+		return first.Stats.Speed < second.Stats.Speed
+	}
+	return b.rng.Intn(2) == 0
+}
+
+func (b *Battle) attack(attacker, defender *Combatant, move *Move) Event {
+	if move != &Struggle {
+		move.PP--
+	}
+	event := Event{
+		Attacker:      attacker.Name,
+		Defender:      defender.Name,
+		Move:          move.Name,
+		Effectiveness: 1,
+	}
+	if move.Accuracy > 0 && b.rng.Intn(100) >= move.Accuracy {
+		event.Missed = true
+		return event
+	}
+	if move.Power == 0 {
+		event.NoDamage = true
+		return event
+	}
+	if move.Type != "" && b.chart != nil {
+		event.Effectiveness = b.chart.Effectiveness(move.Type, defender.Types...)
+	}
+	if event.Effectiveness == 0 {
+		return event
+	}
+
+	modifier := event.Effectiveness * float64(85+b.rng.Intn(16)) / 100
+	if slices.Contains(attacker.Types, move.Type) {
+		modifier *= stabMultiplier
+	}
+	if b.rng.Intn(criticalChance) == 0 {
+		event.Critical = true
+		modifier *= criticalMultiplier
+	}
+	attack, defense := attacker.Stats.Attack, defender.Stats.Defense
+	if move.DamageClass == "special" {
+		attack, defense = attacker.Stats.SpecialAttack, defender.Stats.SpecialDefense
+	}
+	event.Damage = Damage(attacker.Level, move.Power, attack, defense, modifier)
+	defender.HP = max(defender.HP-event.Damage, 0)
+	event.Fainted = defender.Fainted()
+	return event
+}
+
+// Damage applies the mainline damage formula. modifier combines STAB, type
+// effectiveness, critical hits and the random factor.
+func Damage(level, power, attack, defense int, modifier float64) int {
+	defense = max(defense, 1)
+	base := (2*level/5+2)*power*attack/defense/50 + 2
+	return max(int(float64(base)*modifier), 1)
+}
@@ -0,0 +1,125 @@
+package battle
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/faust-m/pokedexcli/internal/stats"
+)
+
+type neutralChart struct{}
+
+func (neutralChart) Effectiveness(string, ...string) float64 {
+	return 1
+}
+
+type immuneChart struct{}
+
+func (immuneChart) Effectiveness(string, ...string) float64 {
+	return 0
+}
+
+func newCombatant(name string, speed int, moves ...Move) *Combatant {
+	s := stats.Stats{HP: 50, Attack: 50, Defense: 50, SpecialAttack: 50, SpecialDefense: 50, Speed: speed}
+	return &Combatant{Name: name, Level: 20, Types: []string{"normal"}, Stats: s, HP: s.HP, Moves: moves}
+}
+
+func tackle() Move {
+	return Move{Name: "tackle", Type: "normal", DamageClass: "physical", Power: 40, Accuracy: 100, PP: 35, MaxPP: 35}
+}
+
+func TestDamage(t *testing.T) {
+	// Level 50, 80 power, equal stats: (22*80*1)/50 + 2 = 37.
+	if d := Damage(50, 80, 100, 100, 1); d != 37 {
+		t.Errorf("expected 37 damage, got %d", d)
+		t.Fail()
+	}
+	if d := Damage(50, 80, 100, 100, 1.5); d != 55 {
+		t.Errorf("expected 55 damage with STAB, got %d", d)
+		t.Fail()
+	}
+	if d := Damage(1, 10, 1, 500, 0.25); d != 1 {
+		t.Errorf("damage should never drop below 1, got %d", d)
+		t.Fail()
+	}
+}
+
+func TestTurnSpeedOrder(t *testing.T) {
+	player := newCombatant("slow", 10, tackle())
+	wild := newCombatant("fast", 100, tackle())
+	b := New(player, wild, neutralChart{}, rand.New(rand.NewSource(1)))
+	events, err := b.Turn("tackle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Attacker != "fast" {
+		t.Errorf("faster combatant should act first: %+v", events)
+		t.Fail()
+	}
+	if player.Moves[0].PP != 34 {
+		t.Errorf("expected tackle PP to drop to 34, got %d", player.Moves[0].PP)
+		t.Fail()
+	}
+}
+
+func TestTurnPriority(t *testing.T) {
+	quick := Move{Name: "quick-attack", Type: "normal", DamageClass: "physical", Power: 40, Accuracy: 100, Priority: 1, PP: 30}
+	player := newCombatant("slow", 10, quick)
+	wild := newCombatant("fast", 100, tackle())
+	b := New(player, wild, neutralChart{}, rand.New(rand.NewSource(1)))
+	events, _ := b.Turn("quick-attack")
+	if events[0].Attacker != "slow" {
+		t.Errorf("priority move should act first: %+v", events)
+		t.Fail()
+	}
+}
+
+func TestTurnUntilFainted(t *testing.T) {
+	player := newCombatant("player", 100, tackle())
+	wild := newCombatant("wild", 10, tackle())
+	b := New(player, wild, neutralChart{}, rand.New(rand.NewSource(1)))
+	for i := 0; i < 100 && !b.Over(); i++ {
+		if _, err := b.Turn("tackle"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !b.Over() {
+		t.Fatalf("battle did not end")
+	}
+	if _, err := b.Turn("tackle"); err == nil {
+		t.Errorf("turn after the battle ended did not err")
+		t.Fail()
+	}
+}
+
+func TestTurnUnknownMove(t *testing.T) {
+	b := New(newCombatant("a", 10, tackle()), newCombatant("b", 10, tackle()), neutralChart{}, rand.New(rand.NewSource(1)))
+	if _, err := b.Turn("hyper-beam"); err == nil {
+		t.Errorf("unknown move did not err")
+		t.Fail()
+	}
+}
+
+func TestStruggleWithoutPP(t *testing.T) {
+	empty := tackle()
+	empty.PP = 0
+	b := New(newCombatant("a", 100, empty), newCombatant("b", 10, tackle()), neutralChart{}, rand.New(rand.NewSource(1)))
+	events, err := b.Turn("tackle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[0].Move != "struggle" {
+		t.Errorf("expected struggle, got %s", events[0].Move)
+		t.Fail()
+	}
+}
+
+func TestImmune(t *testing.T) {
+	b := New(newCombatant("a", 100, tackle()), newCombatant("b", 10, tackle()), immuneChart{}, rand.New(rand.NewSource(1)))
+	events, _ := b.Turn("tackle")
+	if events[0].Damage != 0 || !strings.Contains(events[0].String(), "doesn't affect") {
+		t.Errorf("immune defender took damage: %s", events[0])
+		t.Fail()
+	}
+}
@@ -0,0 +1,106 @@
+package catch
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Status is a non-volatile status condition on the target.
+type Status int
+
+const (
+	StatusNone Status = iota
+	StatusSleep
+	StatusFreeze
+	StatusParalysis
+	StatusPoison
+	StatusBurn
+)
+
+// Ball modifiers for the standard Poke Balls.
+const (
+	PokeBall   = 1.0
+	GreatBall  = 1.5
+	UltraBall  = 2.0
+	MasterBall = 255.0
+)
+
+// Balls maps Poke Ball item names to their modifiers.
+var Balls = map[string]float64{
+	"poke-ball":   PokeBall,
+	"great-ball":  GreatBall,
+	"ultra-ball":  UltraBall,
+	"master-ball": MasterBall,
+}
+
+const (
+	maxModifiedRate = 255
+	shakeChecks     = 4
+	shakeRange      = 65536
+)
+
+func (s Status) Modifier() float64 {
+	switch s {
+	case StatusSleep, StatusFreeze:
+		return 2
+	case StatusParalysis, StatusPoison, StatusBurn:
+		return 1.5
+	}
+	return 1
+}
+
+type Attempt struct {
+	CaptureRate int
+	MaxHP       int
+	CurrentHP   int
+	Ball        float64
+	Status      Status
+}
+
+type Result struct {
+	Caught bool
+	Shakes int
+}
+
+type Engine struct {
+	rng *rand.Rand
+}
+
+func NewEngine(seed int64) *Engine {
+	return &Engine{rng: rand.New(rand.NewSource(seed))}
+}
+
+// ModifiedRate computes the modified catch rate used by the Generation III
+// and IV formula. A rate of 255 or more is a guaranteed catch.
+func ModifiedRate(a Attempt) float64 {
+	maxHP := max(a.MaxHP, 1)
+	currentHP := min(max(a.CurrentHP, 1), maxHP)
+	ball := a.Ball
+	if ball == 0 {
+		ball = PokeBall
+	}
+	return float64(3*maxHP-2*currentHP) * float64(a.CaptureRate) * ball / float64(3*maxHP) * a.Status.Modifier()
+}
+
+// Throw performs up to four shake checks; the Pokemon is caught if all four
+// pass.
+func (e *Engine) Throw(a Attempt) Result {
+	rate := ModifiedRate(a)
+	if rate >= maxModifiedRate {
+		return Result{Caught: true, Shakes: shakeChecks}
+	}
+	if rate <= 0 {
+		return Result{}
+	}
+	b := shakeProbability(rate)
+	for shakes := 0; shakes < shakeChecks; shakes++ {
+		if e.rng.Intn(shakeRange) >= b {
+			return Result{Shakes: shakes}
+		}
+	}
+	return Result{Caught: true, Shakes: shakeChecks}
+}
+
+func shakeProbability(rate float64) int {
+	return int(1048560 / math.Sqrt(math.Sqrt(16711680/rate)))
+}
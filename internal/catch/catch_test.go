@@ -0,0 +1,64 @@
+package catch
+
+import (
+	"testing"
+)
+
+func TestMasterBallAlwaysCatches(t *testing.T) {
+	e := NewEngine(1)
+	for i := 0; i < 100; i++ {
+		result := e.Throw(Attempt{CaptureRate: 3, MaxHP: 100, CurrentHP: 100, Ball: MasterBall})
+		if !result.Caught {
+			t.Fatalf("master ball failed on throw %d", i)
+		}
+	}
+}
+
+func TestZeroCaptureRateNeverCatches(t *testing.T) {
+	e := NewEngine(1)
+	if result := e.Throw(Attempt{CaptureRate: 0, MaxHP: 100, CurrentHP: 1}); result.Caught {
+		t.Errorf("capture rate 0 should never catch")
+		t.Fail()
+	}
+}
+
+func TestModifiedRate(t *testing.T) {
+	full := ModifiedRate(Attempt{CaptureRate: 45, MaxHP: 100, CurrentHP: 100})
+	if full != 15 {
+		t.Errorf("expected 15 at full HP, got %v", full)
+		t.Fail()
+	}
+	weak := ModifiedRate(Attempt{CaptureRate: 45, MaxHP: 100, CurrentHP: 1, Status: StatusSleep})
+	if weak <= full*2 {
+		t.Errorf("low HP and sleep should more than double the rate, got %v", weak)
+		t.Fail()
+	}
+}
+
+func TestSeededEngineIsDeterministic(t *testing.T) {
+	attempt := Attempt{CaptureRate: 45, MaxHP: 100, CurrentHP: 50, Ball: GreatBall}
+	a, b := NewEngine(42), NewEngine(42)
+	for i := 0; i < 20; i++ {
+		if a.Throw(attempt) != b.Throw(attempt) {
+			t.Fatalf("engines with the same seed diverged on throw %d", i)
+		}
+	}
+}
+
+func TestWeakenedTargetCaughtMoreOften(t *testing.T) {
+	e := NewEngine(7)
+	count := func(currentHP int) int {
+		caught := 0
+		for i := 0; i < 2000; i++ {
+			if e.Throw(Attempt{CaptureRate: 45, MaxHP: 100, CurrentHP: currentHP}).Caught {
+				caught++
+			}
+		}
+		return caught
+	}
+	full, weak := count(100), count(1)
+	if weak <= full {
+		t.Errorf("weakened target caught %d times vs %d at full HP", weak, full)
+		t.Fail()
+	}
+}
@@ -0,0 +1,100 @@
+package encounters
+
+import (
+	"math/rand"
+	"slices"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+// Walk is the method used when none is chosen and an area has grass or cave
+// encounters.
+const Walk = "walk"
+
+// Slot is one way of encountering a Pokemon in an area. Chance is the
+// percentage of encounters by Method in Version that this slot accounts for.
+type Slot struct {
+	Pokemon  string `json:"pokemon"`
+	Version  string `json:"version"`
+	Method   string `json:"method"`
+	Chance   int    `json:"chance"`
+	MinLevel int    `json:"min_level"`
+	MaxLevel int    `json:"max_level"`
+}
+
+// Slots flattens an area's encounter data into one slot per encounter
+// detail. Conditions such as time of day, swarms and the Poke Radar are not
+// modelled, so conditional slots are included as if always active.
+func Slots(area pokeapi.ExploreResult) []Slot {
+	var slots []Slot
+	for _, e := range area.PokemonEncounters {
+		for _, v := range e.VersionDetails {
+			for _, d := range v.EncounterDetails {
+				slots = append(slots, Slot{
+					Pokemon:  e.Pokemon.Name,
+					Version:  v.Version.Name,
+					Method:   d.Method.Name,
+					Chance:   d.Chance,
+					MinLevel: d.MinLevel,
+					MaxLevel: max(d.MaxLevel, d.MinLevel),
+				})
+			}
+		}
+	}
+	return slots
+}
+
+// Versions lists the game versions with encounters, in the order they
+// first appear.
+func Versions(slots []Slot) []string {
+	var versions []string
+	for _, s := range slots {
+		if !slices.Contains(versions, s.Version) {
+			versions = append(versions, s.Version)
+		}
+	}
+	return versions
+}
+
+// Methods lists the encounter methods available in version, in the order
+// they first appear.
+func Methods(slots []Slot, version string) []string {
+	var methods []string
+	for _, s := range slots {
+		if s.Version == version && !slices.Contains(methods, s.Method) {
+			methods = append(methods, s.Method)
+		}
+	}
+	return methods
+}
+
+// Filter returns the slots for one version and method.
+func Filter(slots []Slot, version, method string) []Slot {
+	var filtered []Slot
+	for _, s := range slots {
+		if s.Version == version && s.Method == method {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// Roll picks a slot weighted by Chance and a level uniformly within its
+// range. It reports false if no slot has a chance of appearing.
+func Roll(rng *rand.Rand, slots []Slot) (Slot, int, bool) {
+	total := 0
+	for _, s := range slots {
+		total += max(s.Chance, 0)
+	}
+	if total == 0 {
+		return Slot{}, 0, false
+	}
+	n := rng.Intn(total)
+	for _, s := range slots {
+		if n < max(s.Chance, 0) {
+			return s, s.MinLevel + rng.Intn(s.MaxLevel-s.MinLevel+1), true
+		}
+		n -= max(s.Chance, 0)
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,57 @@
+package encounters
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func testSlots() []Slot {
+	return []Slot{
+		{Pokemon: "tentacool", Version: "diamond", Method: "surf", Chance: 60, MinLevel: 20, MaxLevel: 30},
+		{Pokemon: "gastrodon", Version: "diamond", Method: "surf", Chance: 5, MinLevel: 20, MaxLevel: 40},
+		{Pokemon: "magikarp", Version: "diamond", Method: "old-rod", Chance: 70, MinLevel: 5, MaxLevel: 15},
+		{Pokemon: "magikarp", Version: "pearl", Method: "old-rod", Chance: 70, MinLevel: 5, MaxLevel: 15},
+		{Pokemon: "missingno", Version: "diamond", Method: "surf", Chance: 0, MinLevel: 1, MaxLevel: 1},
+	}
+}
+
+func TestVersionsAndMethods(t *testing.T) {
+	slots := testSlots()
+	if versions := Versions(slots); !slices.Equal(versions, []string{"diamond", "pearl"}) {
+		t.Errorf("unexpected versions: %v", versions)
+		t.Fail()
+	}
+	if methods := Methods(slots, "pearl"); !slices.Equal(methods, []string{"old-rod"}) {
+		t.Errorf("unexpected pearl methods: %v", methods)
+		t.Fail()
+	}
+}
+
+func TestRoll(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	surf := Filter(testSlots(), "diamond", "surf")
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		slot, level, ok := Roll(rng, surf)
+		if !ok {
+			t.Fatalf("roll found nothing")
+		}
+		if level < slot.MinLevel || level > slot.MaxLevel {
+			t.Fatalf("level %d outside %s's range %d-%d", level, slot.Pokemon, slot.MinLevel, slot.MaxLevel)
+		}
+		counts[slot.Pokemon]++
+	}
+	if counts["missingno"] != 0 {
+		t.Errorf("a slot with no chance was rolled %d times", counts["missingno"])
+		t.Fail()
+	}
+	if counts["tentacool"] < counts["gastrodon"]*4 {
+		t.Errorf("rolls did not follow chance weights: %v", counts)
+		t.Fail()
+	}
+	if _, _, ok := Roll(rng, nil); ok {
+		t.Errorf("rolling an empty table found something")
+		t.Fail()
+	}
+}
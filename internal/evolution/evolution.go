@@ -0,0 +1,188 @@
+package evolution
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+// Conditions describes the state of a caught Pokemon and the player when
+// attempting an evolution. A zero Level means the level is unknown.
+type Conditions struct {
+	Level     int
+	Happiness int
+	Item      string
+	Trade     bool
+	TimeOfDay string
+	Location  string
+}
+
+// Find returns the link for species within chain.
+func Find(chain pokeapi.ChainLink, species string) (pokeapi.ChainLink, bool) {
+	if chain.Species.Name == species {
+		return chain, true
+	}
+	for _, next := range chain.EvolvesTo {
+		if link, ok := Find(next, species); ok {
+			return link, true
+		}
+	}
+	return pokeapi.ChainLink{}, false
+}
+
+// Render writes chain as an indented tree, one species per line with the
+// conditions needed to reach it.
+func Render(w io.Writer, chain pokeapi.ChainLink) error {
+	return render(w, chain, 0)
+}
+
+func render(w io.Writer, link pokeapi.ChainLink, depth int) error {
+	line := link.Species.Name
+	if depth > 0 {
+		conditions := make([]string, 0, len(link.EvolutionDetails))
+		for _, d := range link.EvolutionDetails {
+			conditions = append(conditions, Describe(d))
+		}
+		line = fmt.Sprintf("%s-> %s (%s)", strings.Repeat("  ", depth-1), line, strings.Join(conditions, " or "))
+	}
+	if _, err := fmt.Fprintln(w, line); err != nil {
+		return fmt.Errorf("error writing evolution chain: %w", err)
+	}
+	for _, next := range link.EvolvesTo {
+		if err := render(w, next, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe summarizes an evolution trigger and its requirements, e.g.
+// "level 16" or "use water-stone".
+func Describe(d pokeapi.EvolutionDetail) string {
+	var parts []string
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d", *d.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		if d.Item != nil {
+			parts = append(parts, "use "+d.Item.Name)
+		}
+	case "trade":
+		parts = append(parts, "trade")
+	default:
+		parts = append(parts, d.Trigger.Name)
+	}
+	if d.HeldItem != nil {
+		parts = append(parts, "holding "+d.HeldItem.Name)
+	}
+	if d.TradeSpecies != nil {
+		parts = append(parts, "for "+d.TradeSpecies.Name)
+	}
+	if d.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("friendship %d", *d.MinHappiness))
+	}
+	if d.MinAffection != nil {
+		parts = append(parts, fmt.Sprintf("affection %d", *d.MinAffection))
+	}
+	if d.MinBeauty != nil {
+		parts = append(parts, fmt.Sprintf("beauty %d", *d.MinBeauty))
+	}
+	if d.KnownMove != nil {
+		parts = append(parts, "knowing "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+d.KnownMoveType.Name+" move")
+	}
+	if d.Location != nil {
+		parts = append(parts, "at "+d.Location.Name)
+	}
+	if d.TimeOfDay != "" {
+		parts = append(parts, "during the "+d.TimeOfDay)
+	}
+	if d.NeedsOverworldRain {
+		parts = append(parts, "while raining")
+	}
+	if d.TurnUpsideDown {
+		parts = append(parts, "upside down")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Next returns the species that link can evolve into under c. If none are
+// available, the reason for the first candidate is returned.
+func Next(link pokeapi.ChainLink, c Conditions) (string, error) {
+	if len(link.EvolvesTo) == 0 {
+		return "", fmt.Errorf("%s does not evolve", link.Species.Name)
+	}
+	var firstErr error
+	for _, next := range link.EvolvesTo {
+		for _, d := range next.EvolutionDetails {
+			err := Met(d, c)
+			if err == nil {
+				return next.Species.Name, nil
+			}
+			if firstErr == nil {
+				firstErr = fmt.Errorf("to evolve into %s: %w", next.Species.Name, err)
+			}
+		}
+	}
+	return "", firstErr
+}
+
+// Met reports why c does not satisfy d, or nil if it does. Requirements this
+// game does not model (affection, beauty, known moves, party members, rain
+// and console orientation) are never met.
+func Met(d pokeapi.EvolutionDetail, c Conditions) error {
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil && c.Level < *d.MinLevel {
+			return fmt.Errorf("must reach level %d", *d.MinLevel)
+		}
+		if d.MinLevel == nil && d.MinHappiness == nil && d.Location == nil && d.TimeOfDay == "" {
+			return fmt.Errorf("requires %s", Describe(d))
+		}
+	case "use-item":
+		if d.Item == nil || c.Item != d.Item.Name {
+			return fmt.Errorf("requires %s", Describe(d))
+		}
+	case "trade":
+		if !c.Trade {
+			return fmt.Errorf("requires %s", Describe(d))
+		}
+	default:
+		return fmt.Errorf("requires %s", Describe(d))
+	}
+	if d.HeldItem != nil && c.Item != d.HeldItem.Name {
+		return fmt.Errorf("must hold %s", d.HeldItem.Name)
+	}
+	if d.MinHappiness != nil && c.Happiness < *d.MinHappiness {
+		return fmt.Errorf("requires friendship %d", *d.MinHappiness)
+	}
+	if d.Location != nil && c.Location != d.Location.Name {
+		return fmt.Errorf("must level up at %s", d.Location.Name)
+	}
+	if d.TimeOfDay != "" && c.TimeOfDay != d.TimeOfDay {
+		return fmt.Errorf("must evolve during the %s", d.TimeOfDay)
+	}
+	if d.TradeSpecies != nil || d.MinAffection != nil || d.MinBeauty != nil ||
+		d.KnownMove != nil || d.KnownMoveType != nil || d.PartySpecies != nil ||
+		d.PartyType != nil || d.Gender != nil || d.NeedsOverworldRain || d.TurnUpsideDown {
+		return fmt.Errorf("requires %s", Describe(d))
+	}
+	return nil
+}
+
+// TimeOfDay maps an hour of the day to "day" or "night" as used by
+// EvolutionDetail.TimeOfDay.
+func TimeOfDay(hour int) string {
+	if hour >= 4 && hour < 20 {
+		return "day"
+	}
+	return "night"
+}
@@ -0,0 +1,91 @@
+package evolution
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func eeveeChain() pokeapi.ChainLink {
+	return pokeapi.ChainLink{
+		Species: pokeapi.NamedResource{Name: "eevee"},
+		EvolvesTo: []pokeapi.ChainLink{
+			{
+				Species: pokeapi.NamedResource{Name: "vaporeon"},
+				EvolutionDetails: []pokeapi.EvolutionDetail{{
+					Trigger: pokeapi.NamedResource{Name: "use-item"},
+					Item:    &pokeapi.NamedResource{Name: "water-stone"},
+				}},
+			},
+			{
+				Species: pokeapi.NamedResource{Name: "umbreon"},
+				EvolutionDetails: []pokeapi.EvolutionDetail{{
+					Trigger:      pokeapi.NamedResource{Name: "level-up"},
+					MinHappiness: intPtr(160),
+					TimeOfDay:    "night",
+				}},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, eeveeChain()); err != nil {
+		t.Fatal(err)
+	}
+	want := "eevee\n-> vaporeon (use water-stone)\n-> umbreon (level up, friendship 160, during the night)\n"
+	if buf.String() != want {
+		t.Errorf("unexpected render:\n%s", buf.String())
+		t.Fail()
+	}
+}
+
+func TestNext(t *testing.T) {
+	chain := eeveeChain()
+	if next, err := Next(chain, Conditions{Item: "water-stone"}); err != nil || next != "vaporeon" {
+		t.Errorf("expected vaporeon, got %q (%v)", next, err)
+		t.Fail()
+	}
+	if next, err := Next(chain, Conditions{Happiness: 200, TimeOfDay: "night"}); err != nil || next != "umbreon" {
+		t.Errorf("expected umbreon, got %q (%v)", next, err)
+		t.Fail()
+	}
+	if _, err := Next(chain, Conditions{Happiness: 200, TimeOfDay: "day"}); err == nil {
+		t.Errorf("eevee should not evolve without a stone or at night")
+		t.Fail()
+	}
+	if _, err := Next(chain.EvolvesTo[0], Conditions{}); err == nil || !strings.Contains(err.Error(), "does not evolve") {
+		t.Errorf("vaporeon should not evolve, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestMetLevel(t *testing.T) {
+	d := pokeapi.EvolutionDetail{Trigger: pokeapi.NamedResource{Name: "level-up"}, MinLevel: intPtr(20)}
+	if Met(d, Conditions{Level: 19}) == nil {
+		t.Errorf("level 19 should not meet level 20")
+		t.Fail()
+	}
+	if err := Met(d, Conditions{Level: 20}); err != nil {
+		t.Errorf("level 20 should meet level 20: %v", err)
+		t.Fail()
+	}
+}
+
+func TestFind(t *testing.T) {
+	if link, ok := Find(eeveeChain(), "umbreon"); !ok || link.Species.Name != "umbreon" {
+		t.Errorf("umbreon not found in chain")
+		t.Fail()
+	}
+	if _, ok := Find(eeveeChain(), "pikachu"); ok {
+		t.Errorf("pikachu found in eevee chain")
+		t.Fail()
+	}
+}
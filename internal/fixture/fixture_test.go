@@ -0,0 +1,114 @@
+package fixture
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	u, _ := url.Parse("https://pokeapi.co/api/v2/location-area?offset=20&limit=20")
+	got := Path("fixtures", u)
+	want := filepath.Join("fixtures", "pokeapi.co", "api", "v2", "location-area@limit=20&offset=20.json")
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+		t.Fail()
+	}
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"pikachu"}`))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	recorder := &http.Client{Transport: &Transport{Dir: dir, Mode: ModeRecord}}
+	res, err := recorder.Get(server.URL + "/pokemon/pikachu")
+	if err != nil {
+		t.Fatalf("error recording: %v", err)
+	}
+	res.Body.Close()
+	server.Close()
+
+	replayer := &http.Client{Transport: &Transport{Dir: dir, Mode: ModeReplay}}
+	res, err = replayer.Get(server.URL + "/pokemon/pikachu")
+	if err != nil {
+		t.Fatalf("error replaying: %v", err)
+	}
+	defer res.Body.Close()
+	var data struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
+		t.Fatalf("error decoding replay: %v", err)
+	}
+	if res.StatusCode != http.StatusOK || data.Name != "pikachu" {
+		t.Errorf("unexpected replay: %d %s", res.StatusCode, data.Name)
+		t.Fail()
+	}
+}
+
+func TestReplayMissing(t *testing.T) {
+	replayer := &http.Client{Transport: &Transport{Dir: t.TempDir(), Mode: ModeReplay}}
+	if _, err := replayer.Get("https://pokeapi.co/api/v2/pokemon/missing"); err == nil {
+		t.Errorf("missing fixture did not err")
+		t.Fail()
+	}
+}
+
+func TestTrim(t *testing.T) {
+	body := `{"id": 25, "moves": [
+		{"move": {"name": "growl"}, "version_group_details": [
+			{"level_learned_at": 1, "version_group": {"name": "red-blue"}},
+			{"level_learned_at": 1, "version_group": {"name": "sword-shield"}}]},
+		{"move": {"name": "play-nice"}, "version_group_details": [
+			{"level_learned_at": 1, "version_group": {"name": "sword-shield"}}]}],
+		"names": [{"name": "Pikachu", "language": {"name": "en"}}, {"name": "ピカチュウ", "language": {"name": "ja"}}],
+		"past_types": []}`
+	trim := Trim{Languages: []string{"en"}, VersionGroups: []string{"red-blue"}}
+	data, err := trim.Apply([]byte(body))
+	if err != nil {
+		t.Fatalf("error trimming: %v", err)
+	}
+	want := `{"id":25,"moves":[{"move":{"name":"growl"},"version_group_details":[{"level_learned_at":1,"version_group":{"name":"red-blue"}}]}],"names":[{"language":{"name":"en"},"name":"Pikachu"}],"past_types":[]}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+		t.Fail()
+	}
+}
+
+func TestRecordTrims(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"names":[{"name":"Pikachu","language":{"name":"en"}},{"name":"Pikachu","language":{"name":"fr"}}]}`))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	recorder := &http.Client{Transport: &Transport{Dir: dir, Mode: ModeRecord, Trim: &Trim{Languages: []string{"en"}}}}
+	res, err := recorder.Get(server.URL + "/pokemon-species/pikachu")
+	if err != nil {
+		t.Fatalf("error recording: %v", err)
+	}
+	recorded, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	u, _ := url.Parse(server.URL + "/pokemon-species/pikachu")
+	stored, err := os.ReadFile(Path(dir, u))
+	if err != nil {
+		t.Fatalf("error reading fixture: %v", err)
+	}
+	for name, data := range map[string][]byte{"response": recorded, "fixture": stored} {
+		if strings.Contains(string(data), `"fr"`) {
+			t.Errorf("expected the %s to be trimmed, got %s", name, data)
+			t.Fail()
+		}
+	}
+}
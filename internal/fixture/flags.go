@@ -0,0 +1,24 @@
+package fixture
+
+import (
+	"flag"
+	"net/http"
+)
+
+var (
+	record = flag.Bool("record", false, "record HTTP fixtures from the live API")
+	replay = flag.Bool("replay", true, "replay HTTP fixtures instead of calling the live API")
+)
+
+// FromFlags returns the transport selected by the -record and -replay test
+// flags. With -replay=false and no -record, requests go to the live API.
+func FromFlags(dir string) http.RoundTripper {
+	switch {
+	case *record:
+		return &Transport{Dir: dir, Mode: ModeRecord, Next: http.DefaultTransport, Trim: &DefaultTrim}
+	case *replay:
+		return &Transport{Dir: dir, Mode: ModeReplay}
+	default:
+		return http.DefaultTransport
+	}
+}
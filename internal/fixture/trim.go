@@ -0,0 +1,97 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// Trim limits what a recording keeps, as PokeAPI responses list every
+// language and game. Array entries naming a language, version or version
+// group missing from the matching list are dropped, as are entries whose
+// version details are all dropped, such as a move no kept version group
+// teaches. An empty list keeps everything.
+type Trim struct {
+	Languages     []string
+	Versions      []string
+	VersionGroups []string
+}
+
+// DefaultTrim is how the fixtures in testdata are trimmed: the games the
+// tests play in and the languages they read.
+var DefaultTrim = Trim{
+	Languages:     []string{"en", "de"},
+	Versions:      []string{"red", "blue", "yellow", "diamond", "pearl", "platinum", "heartgold", "soulsilver"},
+	VersionGroups: []string{"red-blue", "yellow", "diamond-pearl", "platinum", "heartgold-soulsilver"},
+}
+
+// detailKeys hold the per-version details of an entry, which is dropped if
+// trimming leaves them empty.
+var detailKeys = []string{"version_details", "version_group_details"}
+
+// Apply returns the JSON body with the entries the trim does not keep
+// removed. Object keys come out sorted.
+func (t Trim) Apply(body []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(t.trim(v))
+}
+
+func (t Trim) trim(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = t.trim(value)
+		}
+		return v
+	case []any:
+		kept := v[:0]
+		for _, e := range v {
+			if obj, ok := e.(map[string]any); ok {
+				if !t.keeps(obj) {
+					continue
+				}
+			} else {
+				e = t.trim(e)
+			}
+			kept = append(kept, e)
+		}
+		return kept
+	default:
+		return v
+	}
+}
+
+// keeps trims an array entry and reports whether it survives.
+func (t Trim) keeps(obj map[string]any) bool {
+	for key, names := range map[string][]string{
+		"language":      t.Languages,
+		"version":       t.Versions,
+		"version_group": t.VersionGroups,
+	} {
+		ref, ok := obj[key].(map[string]any)
+		if !ok || len(names) == 0 {
+			continue
+		}
+		if name, _ := ref["name"].(string); !slices.Contains(names, name) {
+			return false
+		}
+	}
+	var hadDetails []string
+	for _, key := range detailKeys {
+		if details, ok := obj[key].([]any); ok && len(details) > 0 {
+			hadDetails = append(hadDetails, key)
+		}
+	}
+	t.trim(obj)
+	for _, key := range hadDetails {
+		if len(obj[key].([]any)) == 0 {
+			return false
+		}
+	}
+	return true
+}
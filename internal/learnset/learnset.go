@@ -0,0 +1,133 @@
+package learnset
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+// MaxMoves is how many moves a Pokemon can know at once.
+const MaxMoves = 4
+
+const LevelUp = "level-up"
+
+// methodOrder is the order learn methods are listed in. Others sort last.
+var methodOrder = []string{LevelUp, "machine", "tutor", "egg"}
+
+// versionGroupOrder is the order version groups were released in, as the
+// version group details of a Pokemon's moves come in no particular order.
+// Groups added after this list was written are newer, so they sort last.
+var versionGroupOrder = []string{
+	"red-green-japan", "blue-japan", "red-blue", "yellow",
+	"gold-silver", "crystal",
+	"ruby-sapphire", "emerald", "firered-leafgreen", "colosseum", "xd",
+	"diamond-pearl", "platinum", "heartgold-soulsilver",
+	"black-white", "black-2-white-2",
+	"x-y", "omega-ruby-alpha-sapphire",
+	"sun-moon", "ultra-sun-ultra-moon", "lets-go-pikachu-lets-go-eevee",
+	"sword-shield", "the-isle-of-armor", "the-crown-tundra", "brilliant-diamond-shining-pearl", "legends-arceus",
+	"scarlet-violet", "the-teal-mask", "the-indigo-disk",
+}
+
+// Entry is one way a Pokemon learns a move in a version group. Level is
+// only meaningful for level-up moves.
+type Entry struct {
+	Move   string
+	Method string
+	Level  int
+}
+
+// VersionGroups lists the version groups a Pokemon has moves in, oldest
+// first.
+func VersionGroups(p pokeapi.Pokemon) []string {
+	var groups []string
+	for _, m := range p.Moves {
+		for _, d := range m.VersionGroupDetails {
+			if !slices.Contains(groups, d.VersionGroup.Name) {
+				groups = append(groups, d.VersionGroup.Name)
+			}
+		}
+	}
+	slices.SortStableFunc(groups, func(a, b string) int {
+		return cmp.Compare(versionGroupRank(a), versionGroupRank(b))
+	})
+	return groups
+}
+
+// Latest returns the newest version group a Pokemon has moves in, or "".
+func Latest(p pokeapi.Pokemon) string {
+	groups := VersionGroups(p)
+	if len(groups) == 0 {
+		return ""
+	}
+	return groups[len(groups)-1]
+}
+
+// For returns a Pokemon's learnset in a version group, sorted by method,
+// then level, then name.
+func For(p pokeapi.Pokemon, versionGroup string) []Entry {
+	var entries []Entry
+	for _, m := range p.Moves {
+		for _, d := range m.VersionGroupDetails {
+			if d.VersionGroup.Name == versionGroup {
+				entries = append(entries, Entry{
+					Move:   m.Move.Name,
+					Method: d.MoveLearnMethod.Name,
+					Level:  d.LevelLearnedAt,
+				})
+			}
+		}
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return cmp.Or(
+			cmp.Compare(methodRank(a.Method), methodRank(b.Method)),
+			cmp.Compare(a.Level, b.Level),
+			cmp.Compare(a.Move, b.Move),
+		)
+	})
+	return entries
+}
+
+func methodRank(method string) int {
+	if i := slices.Index(methodOrder, method); i != -1 {
+		return i
+	}
+	return len(methodOrder)
+}
+
+func versionGroupRank(group string) int {
+	if i := slices.Index(versionGroupOrder, group); i != -1 {
+		return i
+	}
+	return len(versionGroupOrder)
+}
+
+// CanLearn reports whether a Pokemon at level can learn move by levelling
+// up, including moves it has since forgotten.
+func CanLearn(entries []Entry, move string, level int) bool {
+	return slices.ContainsFunc(entries, func(e Entry) bool {
+		return e.Method == LevelUp && e.Move == move && e.Level <= level
+	})
+}
+
+// Between returns the level-up moves learned on reaching a level above from
+// and at or below to, in level order.
+func Between(entries []Entry, from, to int) []string {
+	var moves []string
+	for _, e := range entries {
+		if e.Method == LevelUp && e.Level > from && e.Level <= to && !slices.Contains(moves, e.Move) {
+			moves = append(moves, e.Move)
+		}
+	}
+	return moves
+}
+
+// Default returns the moves a Pokemon knows at level when nothing else
+// has been taught: the last four it learned by levelling up, most recent
+// first.
+func Default(entries []Entry, level int) []string {
+	moves := Between(entries, -1, level)
+	slices.Reverse(moves)
+	return moves[:min(len(moves), MaxMoves)]
+}
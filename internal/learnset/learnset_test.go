@@ -0,0 +1,76 @@
+package learnset
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+func testEntries() []Entry {
+	return []Entry{
+		{Move: "growl", Method: LevelUp, Level: 1},
+		{Move: "thunder-shock", Method: LevelUp, Level: 1},
+		{Move: "tail-whip", Method: LevelUp, Level: 5},
+		{Move: "thunder-wave", Method: LevelUp, Level: 10},
+		{Move: "quick-attack", Method: LevelUp, Level: 13},
+		{Move: "thunderbolt", Method: "machine"},
+	}
+}
+
+func TestDefault(t *testing.T) {
+	moves := Default(testEntries(), 13)
+	want := []string{"quick-attack", "thunder-wave", "tail-whip", "thunder-shock"}
+	if !slices.Equal(moves, want) {
+		t.Errorf("expected %v, got %v", want, moves)
+		t.Fail()
+	}
+	if moves := Default(testEntries(), 4); len(moves) != 2 {
+		t.Errorf("expected 2 moves at level 4, got %v", moves)
+		t.Fail()
+	}
+}
+
+func TestBetween(t *testing.T) {
+	if moves := Between(testEntries(), 5, 12); !slices.Equal(moves, []string{"thunder-wave"}) {
+		t.Errorf("expected thunder-wave between levels 5 and 12, got %v", moves)
+		t.Fail()
+	}
+}
+
+func TestCanLearn(t *testing.T) {
+	entries := testEntries()
+	if !CanLearn(entries, "tail-whip", 5) {
+		t.Errorf("tail-whip should be learnable at level 5")
+		t.Fail()
+	}
+	if CanLearn(entries, "quick-attack", 12) {
+		t.Errorf("quick-attack should not be learnable before level 13")
+		t.Fail()
+	}
+	if CanLearn(entries, "thunderbolt", 100) {
+		t.Errorf("machine moves should not be learnable by levelling up")
+		t.Fail()
+	}
+}
+
+func TestVersionGroups(t *testing.T) {
+	var p pokeapi.Pokemon
+	data := `{"moves": [{"move": {"name": "splash"}, "version_group_details": [
+		{"version_group": {"name": "sword-shield"}},
+		{"version_group": {"name": "platinum"}},
+		{"version_group": {"name": "red-blue"}}]}]}`
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"red-blue", "platinum", "sword-shield"}
+	if groups := VersionGroups(p); !slices.Equal(groups, want) {
+		t.Errorf("expected %v, got %v", want, groups)
+		t.Fail()
+	}
+	if latest := Latest(p); latest != "sword-shield" {
+		t.Errorf("expected sword-shield to be the latest, got %s", latest)
+		t.Fail()
+	}
+}
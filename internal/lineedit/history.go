@@ -0,0 +1,94 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultLimit is the number of entries kept when no limit is given.
+const defaultLimit = 1000
+
+// History is the list of entered lines, oldest first, optionally kept in a
+// file with one line per entry.
+type History struct {
+	entries []string
+	path    string
+	limit   int
+}
+
+// LoadHistory reads up to limit of the most recent entries from path, or
+// defaultLimit if limit is not positive. A missing file is an empty
+// history, and an empty path keeps the history in memory only. The file is
+// trimmed once it holds twice limit entries.
+func LoadHistory(path string, limit int) (*History, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	h := &History{path: path, limit: limit}
+	if path == "" {
+		return h, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	} else if err != nil {
+		return h, fmt.Errorf("error opening history: %w", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return h, fmt.Errorf("error reading history: %w", err)
+	}
+	if len(h.entries) > limit {
+		trim := len(h.entries) >= 2*limit
+		h.entries = h.entries[len(h.entries)-limit:]
+		if trim {
+			if err := os.WriteFile(path, []byte(strings.Join(h.entries, "\n")+"\n"), 0o600); err != nil {
+				return h, fmt.Errorf("error trimming history: %w", err)
+			}
+		}
+	}
+	return h, nil
+}
+
+// Entries returns the history, oldest first.
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Add appends line to the history and its file. Blank lines and repeats of
+// the previous line are skipped.
+func (h *History) Add(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == line) {
+		return nil
+	}
+	h.entries = append(h.entries, line)
+	if len(h.entries) > h.limit {
+		h.entries = h.entries[1:]
+	}
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return fmt.Errorf("error creating history directory: %w", err)
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening history: %w", err)
+	}
+	if _, err := fmt.Fprintln(f, line); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing history: %w", err)
+	}
+	return f.Close()
+}
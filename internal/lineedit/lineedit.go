@@ -0,0 +1,342 @@
+package lineedit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ErrInterrupted is returned by ReadLine when Ctrl-C is pressed.
+var ErrInterrupted = errors.New("interrupted")
+
+// Control keys, as read in raw mode.
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyCtrlJ     = 10
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// Escape sequences sent by special keys, without the leading escape.
+const (
+	seqUp     = "[A"
+	seqDown   = "[B"
+	seqRight  = "[C"
+	seqLeft   = "[D"
+	seqHome   = "[H"
+	seqEnd    = "[F"
+	seqDelete = "[3~"
+)
+
+// Completer returns the possible completions of the last word of line,
+// which is the text before the cursor. Each is a whole word.
+type Completer func(line string) []string
+
+// Editor reads lines from a terminal in raw mode with emacs-style editing
+// keys, history browsing and reverse search, and tab completion.
+type Editor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	history  *History
+	complete Completer
+}
+
+// New returns an editor reading keys from in and drawing to out. history
+// and complete may be nil.
+func New(in io.Reader, out io.Writer, history *History, complete Completer) *Editor {
+	if history == nil {
+		history, _ = LoadHistory("", defaultLimit)
+	}
+	return &Editor{in: bufio.NewReader(in), out: out, history: history, complete: complete}
+}
+
+// line is the state of the line being edited.
+type line struct {
+	prompt string
+	buf    []rune
+	pos    int
+}
+
+func (l *line) insert(r ...rune) {
+	l.buf = slices.Insert(l.buf, l.pos, r...)
+	l.pos += len(r)
+}
+
+func (l *line) set(s string) {
+	l.buf = []rune(s)
+	l.pos = len(l.buf)
+}
+
+// ReadLine shows prompt and returns the line entered, adding it to the
+// history. It returns io.EOF if Ctrl-D is pressed on an empty line or the
+// input ends, and ErrInterrupted for Ctrl-C.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	l := &line{prompt: prompt}
+	entries := e.history.Entries()
+	browsing := len(entries)
+	var draft string
+	lastTab := false
+	for {
+		e.refresh(l)
+		r, seq, err := e.readKey()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(l.buf) > 0 {
+				return e.accept(l)
+			}
+			return "", err
+		}
+		tab := r == keyTab
+		switch {
+		case r == keyEnter || r == keyCtrlJ:
+			return e.accept(l)
+		case r == keyCtrlC:
+			fmt.Fprint(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case r == keyCtrlD && len(l.buf) == 0:
+			fmt.Fprint(e.out, "\r\n")
+			return "", io.EOF
+		case r == keyCtrlD || seq == seqDelete:
+			if l.pos < len(l.buf) {
+				l.buf = slices.Delete(l.buf, l.pos, l.pos+1)
+			}
+		case r == keyBackspace || r == keyCtrlH:
+			if l.pos > 0 {
+				l.buf = slices.Delete(l.buf, l.pos-1, l.pos)
+				l.pos--
+			}
+		case r == keyCtrlA || seq == seqHome || seq == "[1~" || seq == "OH":
+			l.pos = 0
+		case r == keyCtrlE || seq == seqEnd || seq == "[4~" || seq == "OF":
+			l.pos = len(l.buf)
+		case r == keyCtrlB || seq == seqLeft:
+			l.pos = max(l.pos-1, 0)
+		case r == keyCtrlF || seq == seqRight:
+			l.pos = min(l.pos+1, len(l.buf))
+		case r == keyCtrlK:
+			l.buf = l.buf[:l.pos]
+		case r == keyCtrlU:
+			l.buf = l.buf[l.pos:]
+			l.pos = 0
+		case r == keyCtrlW:
+			start := wordStart(l.buf, l.pos)
+			l.buf = slices.Delete(l.buf, start, l.pos)
+			l.pos = start
+		case r == keyCtrlL:
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		case r == keyCtrlP || seq == seqUp:
+			if browsing > 0 {
+				if browsing == len(entries) {
+					draft = string(l.buf)
+				}
+				browsing--
+				l.set(entries[browsing])
+			}
+		case r == keyCtrlN || seq == seqDown:
+			if browsing < len(entries) {
+				browsing++
+				if browsing == len(entries) {
+					l.set(draft)
+				} else {
+					l.set(entries[browsing])
+				}
+			}
+		case r == keyCtrlR:
+			if submit := e.search(l); submit {
+				return e.accept(l)
+			}
+		case tab:
+			e.completeWord(l, lastTab)
+		case r != keyEscape && unicode.IsPrint(r):
+			l.insert(r)
+		}
+		lastTab = tab
+	}
+}
+
+func (e *Editor) accept(l *line) (string, error) {
+	l.pos = len(l.buf)
+	e.refresh(l)
+	fmt.Fprint(e.out, "\r\n")
+	s := string(l.buf)
+	// Failing to save the history should not stop the line from running.
+	if err := e.history.Add(s); err != nil {
+		fmt.Fprintf(e.out, "%v\r\n", err)
+	}
+	return s, nil
+}
+
+// refresh redraws the prompt and line and places the cursor.
+func (e *Editor) refresh(l *line) {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", l.prompt, string(l.buf))
+	if back := len(l.buf) - l.pos; back > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", back)
+	}
+}
+
+// readKey reads a key press: a rune, or for special keys the escape rune
+// and the rest of the escape sequence.
+func (e *Editor) readKey() (rune, string, error) {
+	r, _, err := e.in.ReadRune()
+	if err != nil || r != keyEscape {
+		return r, "", err
+	}
+	if e.in.Buffered() == 0 {
+		return r, "", nil
+	}
+	next, _, err := e.in.ReadRune()
+	if err != nil {
+		return r, "", nil
+	}
+	seq := string(next)
+	if next != '[' && next != 'O' {
+		return r, seq, nil
+	}
+	// A control sequence ends with a byte from '@' to '~'.
+	for e.in.Buffered() > 0 {
+		c, _, err := e.in.ReadRune()
+		if err != nil {
+			break
+		}
+		seq += string(c)
+		if c >= '@' && c <= '~' {
+			break
+		}
+	}
+	return r, seq, nil
+}
+
+// search runs a reverse incremental search of the history, as started by
+// Ctrl-R. Typing narrows the search, Ctrl-R finds the next older match,
+// Enter runs the match and Ctrl-G or Ctrl-C gives up. Any other key leaves
+// the match on the line for editing. It reports whether to submit the line.
+func (e *Editor) search(l *line) bool {
+	entries := e.history.Entries()
+	original := string(l.buf)
+	var query []rune
+	at := len(entries)
+	match := ""
+	find := func(from int) {
+		for i := from; i >= 0; i-- {
+			if strings.Contains(entries[i], string(query)) {
+				at, match = i, entries[i]
+				return
+			}
+		}
+	}
+	for {
+		fmt.Fprintf(e.out, "\r(reverse-i-search)`%s': %s\x1b[K", string(query), match)
+		r, _, err := e.readKey()
+		if err != nil {
+			l.set(original)
+			return false
+		}
+		switch {
+		case r == keyCtrlG || r == keyCtrlC:
+			l.set(original)
+			return false
+		case r == keyEnter || r == keyCtrlJ:
+			if match != "" {
+				l.set(match)
+			}
+			return true
+		case r == keyCtrlR:
+			if len(query) > 0 {
+				find(at - 1)
+			}
+		case r == keyBackspace || r == keyCtrlH:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+				at, match = len(entries), ""
+				if len(query) > 0 {
+					find(len(entries) - 1)
+				}
+			}
+		case r != keyEscape && unicode.IsPrint(r):
+			query = append(query, r)
+			find(min(at, len(entries)-1))
+		default:
+			if match != "" {
+				l.set(match)
+			}
+			return false
+		}
+	}
+}
+
+// completeWord completes the word before the cursor. A single completion is
+// inserted with a trailing space; otherwise the longest common prefix is,
+// and pressing tab again lists the choices.
+func (e *Editor) completeWord(l *line, again bool) {
+	if e.complete == nil {
+		return
+	}
+	start := l.pos
+	for start > 0 && l.buf[start-1] != ' ' {
+		start--
+	}
+	word := string(l.buf[start:l.pos])
+	candidates := e.complete(string(l.buf[:l.pos]))
+	switch {
+	case len(candidates) == 0:
+		fmt.Fprint(e.out, "\a")
+	case len(candidates) == 1:
+		l.buf = slices.Delete(l.buf, start, l.pos)
+		l.pos = start
+		l.insert([]rune(candidates[0] + " ")...)
+	default:
+		prefix := commonPrefix(candidates)
+		if len(prefix) > len(word) && strings.HasPrefix(prefix, word) {
+			l.buf = slices.Delete(l.buf, start, l.pos)
+			l.pos = start
+			l.insert([]rune(prefix)...)
+		} else if again {
+			fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+		} else {
+			fmt.Fprint(e.out, "\a")
+		}
+	}
+}
+
+// wordStart is the index of the start of the word before pos, skipping
+// spaces just before it.
+func wordStart(buf []rune, pos int) int {
+	start := pos
+	for start > 0 && buf[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && buf[start-1] != ' ' {
+		start--
+	}
+	return start
+}
+
+// commonPrefix is the longest prefix of whole runes shared by words.
+func commonPrefix(words []string) string {
+	prefix := []rune(words[0])
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, string(prefix)) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return string(prefix)
+}
@@ -0,0 +1,155 @@
+package lineedit
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func readLines(t *testing.T, e *Editor, n int) []string {
+	t.Helper()
+	var lines []string
+	for i := 0; i < n; i++ {
+		line, err := e.ReadLine("> ")
+		if err != nil {
+			t.Fatalf("error reading line %d: %v", i, err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestEditing(t *testing.T) {
+	input := "helo\x1b[Dl\r" + // insert before the cursor
+		"world\x01hello \r" + // Ctrl-A to the start
+		"catch pikachu\x17magikarp\r" + // Ctrl-W deletes a word
+		"abc\x02\x02\x0b\r" + // Ctrl-B twice then Ctrl-K
+		"xy\x7f\x7fz\r" // backspace
+	e := New(strings.NewReader(input), io.Discard, nil, nil)
+	want := []string{"hello", "hello world", "catch magikarp", "a", "z"}
+	if got := readLines(t, e, len(want)); !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+		t.Fail()
+	}
+	if _, err := e.ReadLine("> "); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF at the end of input, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestControlKeys(t *testing.T) {
+	e := New(strings.NewReader("partial\x03\x04"), io.Discard, nil, nil)
+	if _, err := e.ReadLine("> "); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("expected Ctrl-C to interrupt, got %v", err)
+		t.Fail()
+	}
+	if _, err := e.ReadLine("> "); !errors.Is(err, io.EOF) {
+		t.Errorf("expected Ctrl-D on an empty line to be io.EOF, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestHistoryBrowsingAndSearch(t *testing.T) {
+	history, _ := LoadHistory("", 10)
+	input := "explore canalave-city-area\r" +
+		"catch tentacool\r" +
+		"\x1b[A\r" + // up: the previous line
+		"\x1b[A\x1b[A\x1b[A\r" + // up stops at the oldest line
+		"draft\x1b[A\x1b[B\r" + // down returns to the draft
+		"\x12expl\r" + // Ctrl-R finds explore
+		"\x12catch\x12\x1b[C\r" // the match is kept for editing
+	e := New(strings.NewReader(input), io.Discard, history, nil)
+	want := []string{
+		"explore canalave-city-area",
+		"catch tentacool",
+		"catch tentacool",
+		"explore canalave-city-area",
+		"draft",
+		"explore canalave-city-area",
+		"catch tentacool",
+	}
+	if got := readLines(t, e, len(want)); !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+		t.Fail()
+	}
+	if entries := history.Entries(); len(entries) != len(want)-1 {
+		t.Errorf("expected a repeat of the previous line to be skipped, got %q", entries)
+		t.Fail()
+	}
+}
+
+func TestDefaultHistory(t *testing.T) {
+	e := New(strings.NewReader("where\r\x1b[A\r"), io.Discard, nil, nil)
+	want := []string{"where", "where"}
+	if got := readLines(t, e, len(want)); !slices.Equal(got, want) {
+		t.Errorf("expected the editor's own history to be browsable, got %q", got)
+		t.Fail()
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	// "é" and "è" share their first byte but not a whole rune.
+	if got := commonPrefix([]string{"flabébé", "flabèbè"}); got != "flab" {
+		t.Errorf("expected flab, got %q", got)
+		t.Fail()
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	words := []string{"cache", "catch", "explore"}
+	var lines []string
+	complete := func(line string) []string {
+		lines = append(lines, line)
+		fields := strings.Fields(line)
+		var matches []string
+		for _, w := range words {
+			if len(fields) > 0 && strings.HasPrefix(w, fields[len(fields)-1]) {
+				matches = append(matches, w)
+			}
+		}
+		return matches
+	}
+	e := New(strings.NewReader("ex\t\rc\t\rcatc\tpikachu\r"), io.Discard, nil, complete)
+	want := []string{"explore ", "ca", "catch pikachu"}
+	if got := readLines(t, e, len(want)); !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+		t.Fail()
+	}
+	if !slices.Equal(lines, []string{"ex", "c", "catc"}) {
+		t.Errorf("expected the text before the cursor to be completed, got %q", lines)
+		t.Fail()
+	}
+}
+
+func TestHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedexcli", "history")
+	history, err := LoadHistory(path, 3)
+	if err != nil {
+		t.Fatalf("error loading a missing history: %v", err)
+	}
+	for _, line := range []string{"a", "b", "", "c", "d", "e", "f"} {
+		if err := history.Add(line); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reloaded, err := LoadHistory(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries := reloaded.Entries(); !slices.Equal(entries, []string{"d", "e", "f"}) {
+		t.Errorf("expected the last 3 entries, got %q", entries)
+		t.Fail()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "d\ne\nf\n" {
+		t.Errorf("expected the history file to be trimmed, got %q", data)
+		t.Fail()
+	}
+}
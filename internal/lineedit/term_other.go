@@ -0,0 +1,15 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package lineedit
+
+import "errors"
+
+// IsTerminal always reports false, as raw mode is not supported on this
+// platform.
+func IsTerminal(fd int) bool {
+	return false
+}
+
+func MakeRaw(fd int) (func() error, error) {
+	return nil, errors.New("line editing is not supported on this platform")
+}
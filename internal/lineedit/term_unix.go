@@ -0,0 +1,53 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package lineedit
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+func getTermios(fd int) (syscall.Termios, error) {
+	var t syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlGetTermios, uintptr(unsafe.Pointer(&t)))
+	if errno != 0 {
+		return t, errno
+	}
+	return t, nil
+}
+
+func setTermios(fd int, t syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlSetTermios, uintptr(unsafe.Pointer(&t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// IsTerminal reports whether fd is a terminal.
+func IsTerminal(fd int) bool {
+	_, err := getTermios(fd)
+	return err == nil
+}
+
+// MakeRaw puts the terminal fd into raw mode, so keys are read one at a
+// time without echo or signals, and returns a func restoring the previous
+// mode. Output processing is left on, so "\n" still starts a new line.
+func MakeRaw(fd int) (func() error, error) {
+	old, err := getTermios(fd)
+	if err != nil {
+		return nil, fmt.Errorf("error getting terminal mode: %w", err)
+	}
+	raw := old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := setTermios(fd, raw); err != nil {
+		return nil, fmt.Errorf("error setting terminal mode: %w", err)
+	}
+	return func() error { return setTermios(fd, old) }, nil
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Format is a way of writing command results.
+type Format string
+
+const (
+	Text  Format = "text"
+	JSON  Format = "json"
+	CSV   Format = "csv"
+	Table Format = "table"
+)
+
+var Formats = []Format{Text, JSON, CSV, Table}
+
+// Parse looks up a format by name.
+func Parse(name string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %s, use one of text, json, csv or table", name)
+}
+
+// Result is the structured output of a command. It is encoded as is for
+// JSON, so its exported fields should carry json tags.
+type Result interface {
+	// Text writes the result for people to read.
+	Text(w io.Writer) error
+	// Rows returns the result as a header row followed by data rows, for
+	// CSV and tables.
+	Rows() [][]string
+}
+
+// Render writes r to w in format f. An empty format is Text.
+func Render(w io.Writer, f Format, r Result) error {
+	var err error
+	switch f {
+	case Text, "":
+		err = r.Text(w)
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(r)
+	case CSV:
+		cw := csv.NewWriter(w)
+		cw.WriteAll(r.Rows())
+		err = cw.Error()
+	case Table:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, row := range r.Rows() {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		err = tw.Flush()
+	default:
+		return fmt.Errorf("unknown output format %s", f)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+	return nil
+}
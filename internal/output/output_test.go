@@ -0,0 +1,62 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+type testResult struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func (r testResult) Text(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s: %d\n", r.Name, r.Count)
+	return err
+}
+
+func (r testResult) Rows() [][]string {
+	return [][]string{{"name", "count"}, {r.Name, fmt.Sprint(r.Count)}}
+}
+
+func TestRender(t *testing.T) {
+	r := testResult{Name: "poke-ball, great", Count: 10}
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{Text, "poke-ball, great: 10\n"},
+		{JSON, "{\n  \"name\": \"poke-ball, great\",\n  \"count\": 10\n}\n"},
+		{CSV, "name,count\n\"poke-ball, great\",10\n"},
+		{Table, "name              count\npoke-ball, great  10\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := Render(&buf, tt.format, r); err != nil {
+			t.Fatalf("error rendering %s: %v", tt.format, err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("rendering %s: got %q, want %q", tt.format, got, tt.want)
+			t.Fail()
+		}
+	}
+	if err := Render(&bytes.Buffer{}, "yaml", r); err == nil {
+		t.Errorf("rendering an unknown format did not err")
+		t.Fail()
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, f := range Formats {
+		if got, err := Parse(string(f)); err != nil || got != f {
+			t.Errorf("Parse(%q) = %q, %v", f, got, err)
+			t.Fail()
+		}
+	}
+	if _, err := Parse("yaml"); err == nil {
+		t.Errorf("parsing an unknown format did not err")
+		t.Fail()
+	}
+}
@@ -0,0 +1,234 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/faust-m/pokedexcli/internal/pokecache"
+)
+
+const (
+	defaultTimeout   = 10 * time.Second
+	defaultCacheTTL  = 5 * time.Minute
+	defaultUserAgent = "pokedexcli"
+)
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	cache      *pokecache.Cache
+	userAgent  string
+	timeout    time.Duration
+
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	limiter     *limiter
+	flights     flightGroup
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+func WithCache(cache *pokecache.Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout bounds each request made by the client. Zero disables the
+// timeout, leaving cancellation to the caller's context.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:   BaseURL,
+		userAgent: defaultUserAgent,
+		timeout:   defaultTimeout,
+
+		maxAttempts: defaultMaxAttempts,
+		baseBackoff: defaultBaseBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		limiter:     newLimiter(defaultRequestsPerSecond, defaultBurst),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{}
+	}
+	if c.cache == nil {
+		c.cache = pokecache.NewCache(defaultCacheTTL)
+	}
+	return c
+}
+
+func (c *Client) Cache() *pokecache.Cache {
+	return c.cache
+}
+
+func (c *Client) endpointURL(elems ...string) (string, error) {
+	for i := range elems {
+		elems[i] = url.PathEscape(elems[i])
+	}
+	requestURL, err := url.JoinPath(c.baseURL, elems...)
+	if err != nil {
+		return "", fmt.Errorf("error building request URL: %w", err)
+	}
+	return requestURL, nil
+}
+
+func fetch[T any](ctx context.Context, c *Client, requestURL string) (T, error) {
+	var data T
+	if result, found := c.cache.Get(requestURL); found {
+		if err := json.Unmarshal(result, &data); err != nil {
+			return data, fmt.Errorf("error deserializing cached data: %w", err)
+		}
+		return data, nil
+	}
+	body, err := c.getShared(ctx, requestURL, validJSON)
+	if err != nil {
+		return data, err
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return data, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return data, nil
+}
+
+func validJSON(body []byte) error {
+	if !json.Valid(body) {
+		return fmt.Errorf("error decoding response: invalid JSON")
+	}
+	return nil
+}
+
+// cached returns requestURL's data if it is already cached, without making
+// a request or changing which entries the cache evicts first.
+func cached[T any](c *Client, requestURL string) (T, bool) {
+	var data T
+	body, found := c.cache.Peek(requestURL)
+	if !found || json.Unmarshal(body, &data) != nil {
+		return data, false
+	}
+	return data, true
+}
+
+// getShared fetches and caches requestURL, sharing one request between
+// concurrent callers. Callers check the cache first; the check repeated
+// here peeks so it is not counted as a second miss. Responses that fail
+// check are not cached. If the caller that made the shared request is
+// canceled, the others retry with their own context.
+func (c *Client) getShared(ctx context.Context, requestURL string, check func([]byte) error) ([]byte, error) {
+	for {
+		body, shared, err := c.flights.do(ctx, requestURL, func() ([]byte, error) {
+			if result, found := c.cache.Peek(requestURL); found {
+				return result, nil
+			}
+			body, err := c.get(ctx, requestURL)
+			if err != nil {
+				return nil, err
+			}
+			if err := check(body); err != nil {
+				return nil, err
+			}
+			c.cache.Add(requestURL, body)
+			return body, nil
+		})
+		if shared && err != nil && ctx.Err() == nil &&
+			(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			continue
+		}
+		return body, err
+	}
+}
+
+func (c *Client) get(ctx context.Context, requestURL string) ([]byte, error) {
+	var err error
+	for attempt := 0; ; attempt++ {
+		var body []byte
+		var res *http.Response
+		body, res, err = c.getOnce(ctx, requestURL)
+		if err == nil {
+			return body, nil
+		}
+		if ctx.Err() != nil || attempt+1 >= c.maxAttempts {
+			break
+		}
+		if res != nil && !retryableStatus(res.StatusCode) {
+			break
+		}
+		if res == nil && !retryableError(err) {
+			break
+		}
+		delay, ok := c.backoff(attempt, res)
+		if !ok {
+			return nil, fmt.Errorf("%w: server asked to wait %v", err, delay)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// getOnce performs a single request. The response is returned alongside a
+// status error so the caller can inspect its status and headers.
+func (c *Client) getOnce(ctx context.Context, requestURL string) ([]byte, *http.Response, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting resource: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, res.Body)
+		return nil, res, &StatusError{StatusCode: res.StatusCode, Status: res.Status}
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading response: %w", err)
+	}
+	return body, res, nil
+}
@@ -1,9 +1,20 @@
 package pokeapi
 
 const (
-	BaseURL        = "https://pokeapi.co/api/v2/"
-	LocationAreaEP = "location-area"
-	PokemonEP      = "pokemon"
-	OffsetKey      = "offset"
-	LimitKey       = "limit"
+	BaseURL          = "https://pokeapi.co/api/v2/"
+	LocationAreaEP   = "location-area"
+	PokemonEP        = "pokemon"
+	PokemonSpeciesEP = "pokemon-species"
+	TypeEP           = "type"
+	MoveEP           = "move"
+	GrowthRateEP     = "growth-rate"
+	RegionEP         = "region"
+	LocationEP       = "location"
+	VersionEP        = "version"
+	VersionGroupEP   = "version-group"
+	ItemEP           = "item"
+	AbilityEP        = "ability"
+	NatureEP         = "nature"
+	OffsetKey        = "offset"
+	LimitKey         = "limit"
 )
@@ -0,0 +1,33 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrNotFound    = errors.New("resource not found")
+	ErrRateLimited = errors.New("rate limited by PokeAPI")
+)
+
+// StatusError reports a non-200 response. It matches ErrNotFound and
+// ErrRateLimited with errors.Is.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("response returned with status: %s", e.Status)
+}
+
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRequestsPerSecond = 5
+	defaultBurst             = 10
+)
+
+// WithRateLimit throttles requests to rps per second with bursts of up to
+// burst requests. Cache hits are not throttled. A non-positive rps disables
+// the limiter.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		c.limiter = newLimiter(rps, burst)
+	}
+}
+
+// limiter is a token bucket shared by every request a Client makes.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rps float64, burst int) *limiter {
+	if rps <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done. Tokens are reserved
+// up front, so concurrent callers queue in arrival order.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCountingServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"name":"pikachu"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRateLimitRespected(t *testing.T) {
+	server, calls := newCountingServer(t)
+	c := NewClient(WithBaseURL(server.URL), WithRateLimit(20, 1))
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := c.GetPokemonData(context.Background(), fmt.Sprint(i)); err != nil {
+			t.Fatalf("error fetching: %v", err)
+		}
+	}
+	// The first request uses the burst token; the other five wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 240*time.Millisecond {
+		t.Errorf("6 requests at 20/s finished in %v", elapsed)
+		t.Fail()
+	}
+	if calls.Load() != 6 {
+		t.Errorf("expected 6 requests, got %d", calls.Load())
+		t.Fail()
+	}
+}
+
+func TestRateLimitBypassedByCache(t *testing.T) {
+	server, calls := newCountingServer(t)
+	c := NewClient(WithBaseURL(server.URL), WithRateLimit(1, 1))
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := c.GetPokemonData(context.Background(), "pikachu"); err != nil {
+			t.Fatalf("error fetching: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("cache hits were throttled, took %v", elapsed)
+		t.Fail()
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", calls.Load())
+		t.Fail()
+	}
+}
+
+func TestRateLimitCanceled(t *testing.T) {
+	server, _ := newCountingServer(t)
+	c := NewClient(WithBaseURL(server.URL), WithRateLimit(0.1, 1))
+	if _, err := c.GetPokemonData(context.Background(), "first"); err != nil {
+		t.Fatalf("error fetching: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetPokemonData(ctx, "second"); err == nil {
+		t.Errorf("throttled request should fail when its context ends")
+		t.Fail()
+	}
+}
@@ -1,120 +1,258 @@
 package pokeapi
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
 	"fmt"
-	"net/http"
-	"time"
-
-	"github.com/faust-m/pokedexcli/internal/pokecache"
+	"image"
+	"image/png"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 )
 
-var cache *pokecache.Cache
+const (
+	defaultPageSize = 20
+	// versionListSize fits every game in one page.
+	versionListSize = 100
+	// speciesIndexSize fits every species in one page.
+	speciesIndexSize = 2000
 
-func GetLocationAreas(requestURL string) (LocationArea, error) {
-	if cache == nil {
-		cache = pokecache.NewCache(5 * time.Minute)
-	}
-	if result, found := cache.Get(requestURL); found {
-		var locationData LocationArea
-		err := json.Unmarshal(result, &locationData)
+	pngHeader = "\x89PNG\r\n\x1a\n"
+)
+
+// GetLocationAreas fetches a page of location areas. An empty pageURL
+// requests the first page; otherwise pass a Next or Previous URL.
+func (c *Client) GetLocationAreas(ctx context.Context, pageURL string) (LocationArea, error) {
+	if pageURL == "" {
+		requestURL, err := c.endpointURL(LocationAreaEP)
 		if err != nil {
-			return LocationArea{}, fmt.Errorf("error deserializing cached data: %w", err)
+			return LocationArea{}, err
 		}
-		return locationData, nil
+		q := url.Values{}
+		q.Add(OffsetKey, "0")
+		q.Add(LimitKey, strconv.Itoa(defaultPageSize))
+		pageURL = requestURL + "?" + q.Encode()
 	}
-	res, err := http.Get(requestURL)
+	return fetch[LocationArea](ctx, c, pageURL)
+}
+
+func (c *Client) ExploreArea(ctx context.Context, area string) (ExploreResult, error) {
+	requestURL, err := c.endpointURL(LocationAreaEP, area)
+	if err != nil {
+		return ExploreResult{}, err
+	}
+	data, err := fetch[ExploreResult](ctx, c, requestURL)
 	if err != nil {
-		return LocationArea{}, fmt.Errorf("error getting resource: %w", err)
+		return ExploreResult{}, fmt.Errorf("error exploring %s: %w", area, err)
 	}
-	defer res.Body.Close()
+	return data, nil
+}
 
-	if res.StatusCode != http.StatusOK {
-		return LocationArea{}, fmt.Errorf("response returned with status: %s", res.Status)
+func (c *Client) GetPokemonData(ctx context.Context, name string) (Pokemon, error) {
+	requestURL, err := c.endpointURL(PokemonEP, name)
+	if err != nil {
+		return Pokemon{}, err
 	}
-	decoder := json.NewDecoder(res.Body)
-	var data LocationArea
-	err = decoder.Decode(&data)
+	return fetch[Pokemon](ctx, c, requestURL)
+}
+
+func (c *Client) GetPokemonSpecies(ctx context.Context, name string) (PokemonSpecies, error) {
+	requestURL, err := c.endpointURL(PokemonSpeciesEP, name)
 	if err != nil {
-		return LocationArea{}, fmt.Errorf("error decoding response: %w", err)
+		return PokemonSpecies{}, err
 	}
-	cacheData, err := json.Marshal(&data)
+	return fetch[PokemonSpecies](ctx, c, requestURL)
+}
+
+// GetEvolutionChain fetches the chain at chainURL, as found in
+// PokemonSpecies.EvolutionChain.
+func (c *Client) GetEvolutionChain(ctx context.Context, chainURL string) (EvolutionChain, error) {
+	return fetch[EvolutionChain](ctx, c, chainURL)
+}
+
+func (c *Client) GetType(ctx context.Context, name string) (Type, error) {
+	requestURL, err := c.endpointURL(TypeEP, name)
 	if err != nil {
-		return LocationArea{}, fmt.Errorf("error serializing data: %w", err)
+		return Type{}, err
 	}
-	cache.Add(requestURL, cacheData)
+	return fetch[Type](ctx, c, requestURL)
+}
 
-	return data, nil
+func (c *Client) GetMove(ctx context.Context, name string) (Move, error) {
+	requestURL, err := c.endpointURL(MoveEP, name)
+	if err != nil {
+		return Move{}, err
+	}
+	return fetch[Move](ctx, c, requestURL)
 }
 
-func ExploreArea(requestURL string) (ExploreResult, error) {
-	if cache == nil {
-		cache = pokecache.NewCache(5 * time.Minute)
+func (c *Client) GetGrowthRate(ctx context.Context, name string) (GrowthRate, error) {
+	requestURL, err := c.endpointURL(GrowthRateEP, name)
+	if err != nil {
+		return GrowthRate{}, err
 	}
-	if result, found := cache.Get(requestURL); found {
-		var exploreData ExploreResult
-		err := json.Unmarshal(result, &exploreData)
-		if err != nil {
-			return ExploreResult{}, fmt.Errorf("error deserializing cached data: %w", err)
-		}
-		return exploreData, nil
+	return fetch[GrowthRate](ctx, c, requestURL)
+}
+
+func (c *Client) GetRegion(ctx context.Context, name string) (Region, error) {
+	requestURL, err := c.endpointURL(RegionEP, name)
+	if err != nil {
+		return Region{}, err
 	}
-	res, err := http.Get(requestURL)
+	return fetch[Region](ctx, c, requestURL)
+}
+
+func (c *Client) GetLocation(ctx context.Context, name string) (Location, error) {
+	requestURL, err := c.endpointURL(LocationEP, name)
 	if err != nil {
-		return ExploreResult{}, fmt.Errorf("error getting resource: %w", err)
+		return Location{}, err
 	}
-	defer res.Body.Close()
+	return fetch[Location](ctx, c, requestURL)
+}
 
-	if res.StatusCode != http.StatusOK {
-		return ExploreResult{}, fmt.Errorf("response returned with status: %s", res.Status)
+// GetVersions lists every game version.
+func (c *Client) GetVersions(ctx context.Context) (ResourceList, error) {
+	requestURL, err := c.endpointURL(VersionEP)
+	if err != nil {
+		return ResourceList{}, err
 	}
-	decoder := json.NewDecoder(res.Body)
-	var data ExploreResult
-	err = decoder.Decode(&data)
+	q := url.Values{}
+	q.Add(OffsetKey, "0")
+	q.Add(LimitKey, strconv.Itoa(versionListSize))
+	return fetch[ResourceList](ctx, c, requestURL+"?"+q.Encode())
+}
+
+// GetSpeciesIndex lists every Pokemon species.
+func (c *Client) GetSpeciesIndex(ctx context.Context) (ResourceList, error) {
+	requestURL, err := c.endpointURL(PokemonSpeciesEP)
 	if err != nil {
-		return ExploreResult{}, fmt.Errorf("error decoding response: %w", err)
+		return ResourceList{}, err
 	}
-	cacheData, err := json.Marshal(&data)
+	return fetch[ResourceList](ctx, c, requestURL+"?"+speciesIndexQuery())
+}
+
+// CachedSpeciesNames returns the names in the species index if it has
+// already been fetched.
+func (c *Client) CachedSpeciesNames() []string {
+	requestURL, err := c.endpointURL(PokemonSpeciesEP)
 	if err != nil {
-		return ExploreResult{}, fmt.Errorf("error serializing data: %w", err)
+		return nil
+	}
+	index, _ := cached[ResourceList](c, requestURL+"?"+speciesIndexQuery())
+	names := make([]string, 0, len(index.Results))
+	for _, r := range index.Results {
+		names = append(names, r.Name)
 	}
-	cache.Add(requestURL, cacheData)
+	return names
+}
 
-	return data, nil
+func speciesIndexQuery() string {
+	q := url.Values{}
+	q.Add(OffsetKey, "0")
+	q.Add(LimitKey, strconv.Itoa(speciesIndexSize))
+	return q.Encode()
 }
 
-func GetPokemonData(requestURL string) (Pokemon, error) {
-	if cache == nil {
-		cache = pokecache.NewCache(5 * time.Minute)
+// CachedAreaNames returns the names of location areas known from cached
+// responses: pages of the area list, explored areas and the areas of
+// fetched locations. It never makes a request.
+func (c *Client) CachedAreaNames() []string {
+	areaURL, err := c.endpointURL(LocationAreaEP)
+	if err != nil {
+		return nil
 	}
-	if result, found := cache.Get(requestURL); found {
-		var pokemonData Pokemon
-		err := json.Unmarshal(result, &pokemonData)
-		if err != nil {
-			return Pokemon{}, fmt.Errorf("error deserializing cached data: %w", err)
+	locationURL, err := c.endpointURL(LocationEP)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, key := range c.cache.Keys(areaURL) {
+		rest := strings.TrimPrefix(strings.TrimPrefix(key, areaURL), "/")
+		switch {
+		case rest == "" || strings.HasPrefix(rest, "?"):
+			page, _ := cached[LocationArea](c, key)
+			for _, r := range page.Results {
+				names = append(names, r.Name)
+			}
+		case !strings.ContainsAny(rest, "/?"):
+			if name, err := url.PathUnescape(rest); err == nil {
+				names = append(names, name)
+			}
 		}
-		return pokemonData, nil
 	}
-	res, err := http.Get(requestURL)
+	for _, key := range c.cache.Keys(locationURL + "/") {
+		location, _ := cached[Location](c, key)
+		for _, a := range location.Areas {
+			names = append(names, a.Name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func (c *Client) GetVersion(ctx context.Context, name string) (Version, error) {
+	requestURL, err := c.endpointURL(VersionEP, name)
 	if err != nil {
-		return Pokemon{}, fmt.Errorf("error getting resource: %w", err)
+		return Version{}, err
 	}
-	defer res.Body.Close()
+	return fetch[Version](ctx, c, requestURL)
+}
 
-	if res.StatusCode != http.StatusOK {
-		return Pokemon{}, fmt.Errorf("response returned with status: %s", res.Status)
+func (c *Client) GetVersionGroup(ctx context.Context, name string) (VersionGroup, error) {
+	requestURL, err := c.endpointURL(VersionGroupEP, name)
+	if err != nil {
+		return VersionGroup{}, err
 	}
-	decoder := json.NewDecoder(res.Body)
-	var data Pokemon
-	err = decoder.Decode(&data)
+	return fetch[VersionGroup](ctx, c, requestURL)
+}
+
+func (c *Client) GetItem(ctx context.Context, name string) (Item, error) {
+	requestURL, err := c.endpointURL(ItemEP, name)
 	if err != nil {
-		return Pokemon{}, fmt.Errorf("error decoding response: %w", err)
+		return Item{}, err
 	}
-	cacheData, err := json.Marshal(&data)
+	return fetch[Item](ctx, c, requestURL)
+}
+
+func (c *Client) GetAbility(ctx context.Context, name string) (Ability, error) {
+	requestURL, err := c.endpointURL(AbilityEP, name)
 	if err != nil {
-		return Pokemon{}, fmt.Errorf("error serializing data: %w", err)
+		return Ability{}, err
 	}
-	cache.Add(requestURL, cacheData)
+	return fetch[Ability](ctx, c, requestURL)
+}
 
-	return data, nil
+func (c *Client) GetNature(ctx context.Context, name string) (Nature, error) {
+	requestURL, err := c.endpointURL(NatureEP, name)
+	if err != nil {
+		return Nature{}, err
+	}
+	return fetch[Nature](ctx, c, requestURL)
+}
+
+// GetSprite downloads and decodes a PNG sprite. Sprites are hosted outside
+// PokeAPI, so spriteURL is used as given rather than joined to the base URL.
+func (c *Client) GetSprite(ctx context.Context, spriteURL string) (image.Image, error) {
+	body, found := c.cache.Get(spriteURL)
+	if !found {
+		var err error
+		body, err = c.getShared(ctx, spriteURL, validPNG)
+		if err != nil {
+			return nil, err
+		}
+	}
+	img, err := png.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding sprite: %w", err)
+	}
+	return img, nil
+}
+
+func validPNG(body []byte) error {
+	if !bytes.HasPrefix(body, []byte(pngHeader)) {
+		return fmt.Errorf("error decoding sprite: not a PNG image")
+	}
+	return nil
 }
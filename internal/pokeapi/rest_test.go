@@ -1,13 +1,31 @@
 package pokeapi
 
 import (
-	"fmt"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
+	"time"
+
+	"github.com/faust-m/pokedexcli/internal/fixture"
 )
 
+const fixtureDir = "../../testdata/fixtures"
+
+func newTestClient() *Client {
+	return NewClient(
+		WithHTTPClient(&http.Client{Transport: fixture.FromFlags(fixtureDir)}),
+		WithRateLimit(0, 0),
+	)
+}
+
 func TestInvalidResource(t *testing.T) {
+	c := newTestClient()
 	url := "https://pokeapi.co/api/v2/abcd/invalid"
-	_, err := GetLocationAreas(url)
+	_, err := c.GetLocationAreas(context.Background(), url)
 	if err == nil {
 		t.Errorf("invalid request did not err")
 		t.Fail()
@@ -15,8 +33,8 @@ func TestInvalidResource(t *testing.T) {
 }
 
 func TestValidNonCacheResource(t *testing.T) {
-	url := BaseURL + LocationAreaEP
-	locationData, _ := GetLocationAreas(url)
+	c := newTestClient()
+	locationData, _ := c.GetLocationAreas(context.Background(), "")
 	if len(locationData.Results) == 0 {
 		t.Errorf("valid request has zero result length")
 		t.Fail()
@@ -24,19 +42,337 @@ func TestValidNonCacheResource(t *testing.T) {
 }
 
 func TestValidCacheResource(t *testing.T) {
+	c := newTestClient()
 	url := BaseURL + LocationAreaEP
-	GetLocationAreas(url)
-	if _, found := cache.Get(url); !found {
+	c.GetLocationAreas(context.Background(), url)
+	if _, found := c.cache.Get(url); !found {
 		t.Errorf("request was not cached")
 		t.Fail()
 	}
 }
 
 func TestExploreInvalidArea(t *testing.T) {
-	url := fmt.Sprintf("%s%s/%s", BaseURL, LocationAreaEP, "invalid-area")
-	_, err := ExploreArea(url)
+	c := newTestClient()
+	_, err := c.ExploreArea(context.Background(), "invalid-area")
 	if err == nil {
 		t.Errorf("explore invalid-area did not err")
 		t.Fail()
 	}
 }
+
+func TestExploreArea(t *testing.T) {
+	c := newTestClient()
+	data, err := c.ExploreArea(context.Background(), "canalave-city-area")
+	if err != nil {
+		t.Fatalf("error exploring canalave-city-area: %v", err)
+	}
+	if len(data.PokemonEncounters) == 0 {
+		t.Errorf("canalave-city-area has no encounters")
+		t.Fail()
+	}
+}
+
+func TestGetPokemonData(t *testing.T) {
+	c := newTestClient()
+	data, err := c.GetPokemonData(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("error getting pikachu: %v", err)
+	}
+	if data.Name != "pikachu" || data.BaseExperience == 0 {
+		t.Errorf("unexpected pikachu data: %s %d", data.Name, data.BaseExperience)
+		t.Fail()
+	}
+}
+
+func TestIsolatedClients(t *testing.T) {
+	a := NewClient()
+	b := NewClient()
+	a.cache.Add("key", []byte("value"))
+	if _, found := b.cache.Get("key"); found {
+		t.Errorf("clients share a cache")
+		t.Fail()
+	}
+}
+
+func TestEndpointURL(t *testing.T) {
+	c := NewClient(WithBaseURL("http://localhost:8080/api/v2/"))
+	got, err := c.endpointURL(PokemonEP, "mr-mime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "http://localhost:8080/api/v2/pokemon/mr-mime" {
+		t.Errorf("unexpected endpoint URL: %s", got)
+		t.Fail()
+	}
+}
+
+func TestCanceledContext(t *testing.T) {
+	c := newTestClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetPokemonData(ctx, "pikachu")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+	c := NewClient(WithBaseURL(server.URL), WithTimeout(50*time.Millisecond))
+	_, err := c.GetPokemonData(context.Background(), "pikachu")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestGetPokemonSpecies(t *testing.T) {
+	c := newTestClient()
+	data, err := c.GetPokemonSpecies(context.Background(), "magikarp")
+	if err != nil {
+		t.Fatalf("error getting magikarp species: %v", err)
+	}
+	if data.CaptureRate != 255 || data.GrowthRate.Name != "slow" {
+		t.Errorf("unexpected magikarp species: %d %s", data.CaptureRate, data.GrowthRate.Name)
+		t.Fail()
+	}
+}
+
+func TestDefaultPokemon(t *testing.T) {
+	var species PokemonSpecies
+	data := `{"name": "wormadam", "varieties": [
+		{"is_default": true, "pokemon": {"name": "wormadam-plant"}},
+		{"is_default": false, "pokemon": {"name": "wormadam-sandy"}}]}`
+	if err := json.Unmarshal([]byte(data), &species); err != nil {
+		t.Fatal(err)
+	}
+	if got := species.DefaultPokemon(); got != "wormadam-plant" {
+		t.Errorf("expected wormadam-plant, got %s", got)
+		t.Fail()
+	}
+	if got := (PokemonSpecies{Name: "pikachu"}).DefaultPokemon(); got != "pikachu" {
+		t.Errorf("expected a species without varieties to use its own name, got %s", got)
+		t.Fail()
+	}
+}
+
+func TestGetMove(t *testing.T) {
+	c := newTestClient()
+	data, err := c.GetMove(context.Background(), "thunderbolt")
+	if err != nil {
+		t.Fatalf("error getting thunderbolt: %v", err)
+	}
+	if data.Power == nil || *data.Power != 90 || data.Type.Name != "electric" {
+		t.Errorf("unexpected thunderbolt data: %+v", data)
+		t.Fail()
+	}
+}
+
+func TestGetGrowthRate(t *testing.T) {
+	c := newTestClient()
+	data, err := c.GetGrowthRate(context.Background(), "medium")
+	if err != nil {
+		t.Fatalf("error getting medium growth rate: %v", err)
+	}
+	if len(data.Levels) != 100 || data.Levels[99].Experience != 1000000 {
+		t.Errorf("unexpected medium growth rate levels: %d", len(data.Levels))
+		t.Fail()
+	}
+}
+
+func TestGetRegionAndLocation(t *testing.T) {
+	c := newTestClient()
+	region, err := c.GetRegion(context.Background(), "sinnoh")
+	if err != nil {
+		t.Fatalf("error getting sinnoh: %v", err)
+	}
+	if len(region.Locations) == 0 || region.Locations[0].Name != "canalave-city" {
+		t.Errorf("unexpected sinnoh locations: %+v", region.Locations)
+		t.Fail()
+	}
+	location, err := c.GetLocation(context.Background(), "canalave-city")
+	if err != nil {
+		t.Fatalf("error getting canalave-city: %v", err)
+	}
+	if location.Region.Name != "sinnoh" || len(location.Areas) != 1 {
+		t.Errorf("unexpected canalave-city data: %+v", location)
+		t.Fail()
+	}
+	if name := LocalizedName(location.Names, "en"); name != "Canalave City" {
+		t.Errorf("expected English name Canalave City, got %q", name)
+		t.Fail()
+	}
+	if _, err := c.GetRegion(context.Background(), "atlantis"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for atlantis, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestGetVersions(t *testing.T) {
+	c := newTestClient()
+	versions, err := c.GetVersions(context.Background())
+	if err != nil {
+		t.Fatalf("error listing versions: %v", err)
+	}
+	if versions.Count != len(versions.Results) || versions.Results[0].Name != "red" {
+		t.Errorf("unexpected version list: %+v", versions)
+		t.Fail()
+	}
+	version, err := c.GetVersion(context.Background(), "platinum")
+	if err != nil {
+		t.Fatalf("error getting platinum: %v", err)
+	}
+	group, err := c.GetVersionGroup(context.Background(), version.VersionGroup.Name)
+	if err != nil {
+		t.Fatalf("error getting platinum's version group: %v", err)
+	}
+	if group.Generation.Name != "generation-iv" || len(group.Regions) != 1 || group.Regions[0].Name != "sinnoh" {
+		t.Errorf("unexpected platinum version group: %+v", group)
+		t.Fail()
+	}
+}
+
+func TestPokemonVersionData(t *testing.T) {
+	c := newTestClient()
+	data, err := c.GetPokemonData(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("error getting pikachu: %v", err)
+	}
+	sprites, ok := data.VersionSprites("generation-iv", "platinum")
+	if !ok || sprites.FrontDefault == data.Sprites.FrontDefault {
+		t.Errorf("expected platinum sprites, got %+v", sprites)
+		t.Fail()
+	}
+	if _, ok := data.VersionSprites("generation-ix", "scarlet-violet"); ok {
+		t.Errorf("found sprites for a version group pikachu has none for")
+		t.Fail()
+	}
+	if items := data.HeldItemsIn("diamond"); len(items) != 1 || items[0].Name != "light-ball" {
+		t.Errorf("expected pikachu to hold a light-ball in diamond, got %+v", items)
+		t.Fail()
+	}
+	if items := data.HeldItemsIn("red"); len(items) != 0 {
+		t.Errorf("expected no held items in red, got %+v", items)
+		t.Fail()
+	}
+}
+
+func TestGetItem(t *testing.T) {
+	c := newTestClient()
+	item, err := c.GetItem(context.Background(), "great-ball")
+	if err != nil {
+		t.Fatalf("error getting great-ball: %v", err)
+	}
+	if item.Cost != 600 || item.Category.Name != "standard-balls" {
+		t.Errorf("unexpected great-ball data: %+v", item)
+		t.Fail()
+	}
+}
+
+func TestGetAbility(t *testing.T) {
+	c := newTestClient()
+	ability, err := c.GetAbility(context.Background(), "lightning-rod")
+	if err != nil {
+		t.Fatalf("error getting lightning-rod: %v", err)
+	}
+	if len(ability.Pokemon) != 1 || ability.Pokemon[0].Pokemon.Name != "pikachu" || !ability.Pokemon[0].IsHidden {
+		t.Errorf("expected lightning-rod to be pikachu's hidden ability, got %+v", ability.Pokemon)
+		t.Fail()
+	}
+	if _, err := c.GetAbility(context.Background(), "atlantis"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for atlantis, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestGetNature(t *testing.T) {
+	c := newTestClient()
+	nature, err := c.GetNature(context.Background(), "adamant")
+	if err != nil {
+		t.Fatalf("error getting adamant: %v", err)
+	}
+	if nature.IncreasedStat == nil || nature.IncreasedStat.Name != "attack" ||
+		nature.DecreasedStat == nil || nature.DecreasedStat.Name != "special-attack" {
+		t.Errorf("unexpected adamant data: %+v", nature)
+		t.Fail()
+	}
+	hardy, err := c.GetNature(context.Background(), "hardy")
+	if err != nil {
+		t.Fatalf("error getting hardy: %v", err)
+	}
+	if hardy.IncreasedStat != nil || hardy.DecreasedStat != nil {
+		t.Errorf("expected hardy to be neutral, got %+v", hardy)
+		t.Fail()
+	}
+}
+
+func TestCacheStatsPerFetch(t *testing.T) {
+	c := newTestClient()
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetPokemonData(context.Background(), "pikachu"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats := c.Cache().Stats(); stats.Misses != 1 || stats.Hits != 1 {
+		t.Errorf("expected one miss then one hit, got %d misses and %d hits", stats.Misses, stats.Hits)
+		t.Fail()
+	}
+}
+
+func TestGetSprite(t *testing.T) {
+	c := newTestClient()
+	pikachu, err := c.GetPokemonData(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := c.GetSprite(context.Background(), pikachu.Sprites.FrontDefault)
+	if err != nil {
+		t.Fatalf("error getting pikachu's sprite: %v", err)
+	}
+	if size := img.Bounds().Size(); size.X != 96 || size.Y != 96 {
+		t.Errorf("expected a 96x96 sprite, got %v", size)
+		t.Fail()
+	}
+	if _, found := c.Cache().Get(pikachu.Sprites.FrontDefault); !found {
+		t.Errorf("expected the sprite to be cached")
+		t.Fail()
+	}
+	if _, err := c.GetSprite(context.Background(), BaseURL+"pokemon/pikachu"); err == nil {
+		t.Errorf("getting a JSON resource as a sprite did not err")
+		t.Fail()
+	}
+}
+
+func TestCachedNames(t *testing.T) {
+	c := newTestClient()
+	if names := c.CachedSpeciesNames(); len(names) != 0 {
+		t.Errorf("expected no species before fetching the index, got %v", names)
+		t.Fail()
+	}
+	if _, err := c.GetSpeciesIndex(context.Background()); err != nil {
+		t.Fatalf("error getting the species index: %v", err)
+	}
+	if names := c.CachedSpeciesNames(); !slices.Contains(names, "pikachu") {
+		t.Errorf("expected pikachu in the species index, got %v", names)
+		t.Fail()
+	}
+
+	if _, err := c.ExploreArea(context.Background(), "canalave-city-area"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetLocation(context.Background(), "eterna-forest"); err != nil {
+		t.Fatal(err)
+	}
+	names := c.CachedAreaNames()
+	if !slices.Contains(names, "canalave-city-area") || !slices.Contains(names, "eterna-forest-area") {
+		t.Errorf("expected explored and located areas, got %v", names)
+		t.Fail()
+	}
+}
@@ -0,0 +1,92 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseBackoff = 250 * time.Millisecond
+	defaultMaxBackoff  = 10 * time.Second
+)
+
+// WithMaxAttempts sets how many times a request is tried before giving up.
+// Values below one are treated as one.
+func WithMaxAttempts(n int) Option {
+	return func(c *Client) {
+		c.maxAttempts = n
+	}
+}
+
+// WithBackoff sets the base and maximum delay between retries.
+func WithBackoff(base, max time.Duration) Option {
+	return func(c *Client) {
+		c.baseBackoff = base
+		c.maxBackoff = max
+	}
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+func retryableError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		(errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// backoff returns the delay before retry number attempt, using full jitter
+// over an exponentially growing window. A Retry-After header takes
+// precedence; if it asks for longer than the maximum backoff, ok is false
+// and the request should not be retried.
+func (c *Client) backoff(attempt int, res *http.Response) (d time.Duration, ok bool) {
+	if res != nil {
+		if d, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return d, d <= c.maxBackoff
+		}
+	}
+	window := c.baseBackoff << attempt
+	if window <= 0 || window > c.maxBackoff {
+		window = c.maxBackoff
+	}
+	if window <= 0 {
+		return 0, true
+	}
+	return time.Duration(rand.Int63n(int64(window) + 1)), true
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
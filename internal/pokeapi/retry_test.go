@@ -0,0 +1,115 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"name":"pikachu","base_experience":112}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetryServerError(t *testing.T) {
+	server, calls := newRetryServer(t, 2, http.StatusServiceUnavailable, nil)
+	c := NewClient(WithBaseURL(server.URL), WithBackoff(time.Millisecond, 5*time.Millisecond))
+	data, err := c.GetPokemonData(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("request was not retried: %v", err)
+	}
+	if data.Name != "pikachu" || calls.Load() != 3 {
+		t.Errorf("expected pikachu after 3 calls, got %q after %d", data.Name, calls.Load())
+		t.Fail()
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server, calls := newRetryServer(t, 10, http.StatusTooManyRequests, nil)
+	c := NewClient(WithBaseURL(server.URL), WithMaxAttempts(2), WithBackoff(time.Millisecond, 5*time.Millisecond))
+	_, err := c.GetPokemonData(context.Background(), "pikachu")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+		t.Fail()
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls.Load())
+		t.Fail()
+	}
+}
+
+func TestNoRetryNotFound(t *testing.T) {
+	server, calls := newRetryServer(t, 10, http.StatusNotFound, nil)
+	c := NewClient(WithBaseURL(server.URL), WithBackoff(time.Millisecond, 5*time.Millisecond))
+	_, err := c.GetPokemonData(context.Background(), "pikachu")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+		t.Fail()
+	}
+	if calls.Load() != 1 {
+		t.Errorf("not found should not be retried, got %d attempts", calls.Load())
+		t.Fail()
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"1"}}
+	server, _ := newRetryServer(t, 1, http.StatusTooManyRequests, header)
+	c := NewClient(WithBaseURL(server.URL), WithBackoff(time.Millisecond, 5*time.Second))
+	start := time.Now()
+	if _, err := c.GetPokemonData(context.Background(), "pikachu"); err != nil {
+		t.Fatalf("request was not retried: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Retry-After was not honored, retried after %v", elapsed)
+		t.Fail()
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"60"}}
+	server, calls := newRetryServer(t, 1, http.StatusTooManyRequests, header)
+	c := NewClient(WithBaseURL(server.URL), WithBackoff(time.Millisecond, 10*time.Second))
+	start := time.Now()
+	_, err := c.GetPokemonData(context.Background(), "pikachu")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+		t.Fail()
+	}
+	if calls.Load() != 1 || time.Since(start) > time.Second {
+		t.Errorf("expected to give up at once, got %d attempts after %v", calls.Load(), time.Since(start))
+		t.Fail()
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("3"); !ok || d != 3*time.Second {
+		t.Errorf("expected 3s, got %v", d)
+		t.Fail()
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d <= 0 {
+		t.Errorf("expected a positive delay for %s, got %v", date, d)
+		t.Fail()
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Errorf("invalid Retry-After should not parse")
+		t.Fail()
+	}
+}
@@ -0,0 +1,77 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrentFetchesCoalesced(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Write([]byte(`{"name":"pikachu"}`))
+	}))
+	defer server.Close()
+	c := NewClient(WithBaseURL(server.URL))
+
+	var wg sync.WaitGroup
+	names := make([]string, 10)
+	for i := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := c.GetPokemonData(context.Background(), "pikachu")
+			if err != nil {
+				t.Errorf("error fetching: %v", err)
+			}
+			names[i] = data.Name
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", calls.Load())
+		t.Fail()
+	}
+	for _, name := range names {
+		if name != "pikachu" {
+			t.Errorf("caller got %q instead of pikachu", name)
+			t.Fail()
+		}
+	}
+}
+
+func TestFlightGroupCanceledLeader(t *testing.T) {
+	var g flightGroup
+	started := make(chan struct{})
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	go g.do(leaderCtx, "key", func() ([]byte, error) {
+		close(started)
+		<-leaderCtx.Done()
+		return nil, leaderCtx.Err()
+	})
+	<-started
+
+	done := make(chan error)
+	go func() {
+		ctx, stop := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer stop()
+		_, _, err := g.do(ctx, "key", func() ([]byte, error) { return nil, nil })
+		done <- err
+	}()
+	err := <-done
+	cancel()
+	if err != context.DeadlineExceeded {
+		t.Errorf("waiter should stop at its own deadline, got %v", err)
+		t.Fail()
+	}
+}
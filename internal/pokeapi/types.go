@@ -1,5 +1,7 @@
 package pokeapi
 
+import "encoding/json"
+
 type LocationArea struct {
 	Count    int     `json:"count"`
 	Next     *string `json:"next"`
@@ -333,3 +335,317 @@ type Pokemon struct {
 	} `json:"types"`
 	Weight int `json:"weight"`
 }
+
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type PokemonSpecies struct {
+	ID                 int            `json:"id"`
+	Name               string         `json:"name"`
+	Order              int            `json:"order"`
+	BaseHappiness      int            `json:"base_happiness"`
+	CaptureRate        int            `json:"capture_rate"`
+	GenderRate         int            `json:"gender_rate"`
+	HatchCounter       int            `json:"hatch_counter"`
+	IsBaby             bool           `json:"is_baby"`
+	IsLegendary        bool           `json:"is_legendary"`
+	IsMythical         bool           `json:"is_mythical"`
+	GrowthRate         NamedResource  `json:"growth_rate"`
+	EvolvesFromSpecies *NamedResource `json:"evolves_from_species"`
+	EvolutionChain     struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Generation NamedResource `json:"generation"`
+	Genera     []struct {
+		Genus    string        `json:"genus"`
+		Language NamedResource `json:"language"`
+	} `json:"genera"`
+	FlavorTextEntries []struct {
+		FlavorText string        `json:"flavor_text"`
+		Language   NamedResource `json:"language"`
+		Version    NamedResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Varieties []struct {
+		IsDefault bool          `json:"is_default"`
+		Pokemon   NamedResource `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedResource     `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+type EvolutionDetail struct {
+	Trigger            NamedResource  `json:"trigger"`
+	Item               *NamedResource `json:"item"`
+	HeldItem           *NamedResource `json:"held_item"`
+	KnownMove          *NamedResource `json:"known_move"`
+	KnownMoveType      *NamedResource `json:"known_move_type"`
+	Location           *NamedResource `json:"location"`
+	TradeSpecies       *NamedResource `json:"trade_species"`
+	PartySpecies       *NamedResource `json:"party_species"`
+	PartyType          *NamedResource `json:"party_type"`
+	Gender             *int           `json:"gender"`
+	MinLevel           *int           `json:"min_level"`
+	MinHappiness       *int           `json:"min_happiness"`
+	MinAffection       *int           `json:"min_affection"`
+	MinBeauty          *int           `json:"min_beauty"`
+	TimeOfDay          string         `json:"time_of_day"`
+	NeedsOverworldRain bool           `json:"needs_overworld_rain"`
+	TurnUpsideDown     bool           `json:"turn_upside_down"`
+}
+
+type Type struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageFrom []NamedResource `json:"double_damage_from"`
+		DoubleDamageTo   []NamedResource `json:"double_damage_to"`
+		HalfDamageFrom   []NamedResource `json:"half_damage_from"`
+		HalfDamageTo     []NamedResource `json:"half_damage_to"`
+		NoDamageFrom     []NamedResource `json:"no_damage_from"`
+		NoDamageTo       []NamedResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+	MoveDamageClass *NamedResource  `json:"move_damage_class"`
+	Moves           []NamedResource `json:"moves"`
+	Pokemon         []struct {
+		Slot    int           `json:"slot"`
+		Pokemon NamedResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+type Move struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Accuracy      *int          `json:"accuracy"`
+	Power         *int          `json:"power"`
+	PP            *int          `json:"pp"`
+	Priority      int           `json:"priority"`
+	Type          NamedResource `json:"type"`
+	DamageClass   NamedResource `json:"damage_class"`
+	EffectChance  *int          `json:"effect_chance"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+	Meta *struct {
+		Ailment       NamedResource `json:"ailment"`
+		AilmentChance int           `json:"ailment_chance"`
+		CritRate      int           `json:"crit_rate"`
+		Drain         int           `json:"drain"`
+		FlinchChance  int           `json:"flinch_chance"`
+		Healing       int           `json:"healing"`
+		MaxHits       *int          `json:"max_hits"`
+		MinHits       *int          `json:"min_hits"`
+		StatChance    int           `json:"stat_chance"`
+	} `json:"meta"`
+	LearnedByPokemon []NamedResource `json:"learned_by_pokemon"`
+}
+
+type GrowthRate struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+	Levels  []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+	PokemonSpecies []NamedResource `json:"pokemon_species"`
+}
+
+type Region struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	Locations      []NamedResource `json:"locations"`
+	MainGeneration NamedResource   `json:"main_generation"`
+	Names          []Name          `json:"names"`
+	Pokedexes      []NamedResource `json:"pokedexes"`
+	VersionGroups  []NamedResource `json:"version_groups"`
+}
+
+type Location struct {
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Region NamedResource   `json:"region"`
+	Names  []Name          `json:"names"`
+	Areas  []NamedResource `json:"areas"`
+}
+
+type Item struct {
+	ID            int             `json:"id"`
+	Name          string          `json:"name"`
+	Cost          int             `json:"cost"`
+	FlingPower    *int            `json:"fling_power"`
+	Attributes    []NamedResource `json:"attributes"`
+	Category      NamedResource   `json:"category"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+	Names   []Name `json:"names"`
+	Sprites struct {
+		Default string `json:"default"`
+	} `json:"sprites"`
+}
+
+type Ability struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	IsMainSeries  bool          `json:"is_main_series"`
+	Generation    NamedResource `json:"generation"`
+	Names         []Name        `json:"names"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+	Pokemon []struct {
+		IsHidden bool          `json:"is_hidden"`
+		Slot     int           `json:"slot"`
+		Pokemon  NamedResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+// Nature is a PokeAPI nature. The stats and flavors are nil for the neutral
+// natures.
+type Nature struct {
+	ID            int            `json:"id"`
+	Name          string         `json:"name"`
+	IncreasedStat *NamedResource `json:"increased_stat"`
+	DecreasedStat *NamedResource `json:"decreased_stat"`
+	LikesFlavor   *NamedResource `json:"likes_flavor"`
+	HatesFlavor   *NamedResource `json:"hates_flavor"`
+	Names         []Name         `json:"names"`
+}
+
+type ResourceList struct {
+	Count    int             `json:"count"`
+	Next     *string         `json:"next"`
+	Previous *string         `json:"previous"`
+	Results  []NamedResource `json:"results"`
+}
+
+type Version struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	Names        []Name        `json:"names"`
+	VersionGroup NamedResource `json:"version_group"`
+}
+
+type VersionGroup struct {
+	ID               int             `json:"id"`
+	Name             string          `json:"name"`
+	Order            int             `json:"order"`
+	Generation       NamedResource   `json:"generation"`
+	MoveLearnMethods []NamedResource `json:"move_learn_methods"`
+	Pokedexes        []NamedResource `json:"pokedexes"`
+	Regions          []NamedResource `json:"regions"`
+	Versions         []NamedResource `json:"versions"`
+}
+
+type Name struct {
+	Name     string        `json:"name"`
+	Language NamedResource `json:"language"`
+}
+
+// LocalizedName returns the name in the given language, or "" if there is
+// none.
+func LocalizedName(names []Name, language string) string {
+	for _, n := range names {
+		if n.Language.Name == language {
+			return n.Name
+		}
+	}
+	return ""
+}
+
+type HeldItem struct {
+	Name   string
+	Rarity int
+}
+
+// HeldItemsIn returns the items a wild Pokemon may hold in version and the
+// percentage chance of each. An empty version matches the first version
+// listed for each item.
+func (p Pokemon) HeldItemsIn(version string) []HeldItem {
+	var items []HeldItem
+	for _, held := range p.HeldItems {
+		for _, d := range held.VersionDetails {
+			if version == "" || d.Version.Name == version {
+				items = append(items, HeldItem{Name: held.Item.Name, Rarity: d.Rarity})
+				break
+			}
+		}
+	}
+	return items
+}
+
+type SpriteSet struct {
+	FrontDefault string `json:"front_default"`
+	FrontShiny   string `json:"front_shiny"`
+	BackDefault  string `json:"back_default"`
+	BackShiny    string `json:"back_shiny"`
+}
+
+// VersionSprites returns the sprites used by a version group, which PokeAPI
+// files under its generation. It reports false if there are none, or if
+// the version group is empty, in which case the default sprites are
+// returned.
+func (p Pokemon) VersionSprites(generation, versionGroup string) (SpriteSet, bool) {
+	defaults := SpriteSet{
+		FrontDefault: p.Sprites.FrontDefault,
+		FrontShiny:   p.Sprites.FrontShiny,
+		BackDefault:  p.Sprites.BackDefault,
+		BackShiny:    p.Sprites.BackShiny,
+	}
+	if versionGroup == "" {
+		return defaults, false
+	}
+	// Versions is a deeply nested struct with a field per game, so it is
+	// easier to index by name after a round trip through JSON.
+	data, err := json.Marshal(p.Sprites.Versions)
+	if err != nil {
+		return defaults, false
+	}
+	var versions map[string]map[string]SpriteSet
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return defaults, false
+	}
+	sprites, ok := versions[generation][versionGroup]
+	if !ok || sprites.FrontDefault == "" {
+		return defaults, false
+	}
+	return sprites, true
+}
+
+func (p Pokemon) TypeNames() []string {
+	names := make([]string, 0, len(p.Types))
+	for _, t := range p.Types {
+		names = append(names, t.Type.Name)
+	}
+	return names
+}
+
+// DefaultPokemon returns the name of the species' default Pokemon, which
+// differs from the species name for species with several forms, such as
+// wormadam-plant.
+func (s PokemonSpecies) DefaultPokemon() string {
+	for _, v := range s.Varieties {
+		if v.IsDefault {
+			return v.Pokemon.Name
+		}
+	}
+	return s.Name
+}
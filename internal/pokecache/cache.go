@@ -1,24 +1,88 @@
 package pokecache
 
 import (
+	"container/list"
+	"maps"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
 
 type Cache struct {
-	mu      sync.Mutex
-	entries map[string]cacheEntry
+	mu         sync.Mutex
+	entries    map[string]cacheEntry
+	interval   time.Duration
+	disk       *diskStore
+	lru        *list.List
+	bytes      int64
+	maxBytes   int64
+	maxEntries int
+	stats      Stats
+	// index is when each entry on disk was created, by key. It is read from
+	// the disk the first time Keys is called and kept up to date after that.
+	index     map[string]time.Time
+	indexOnce sync.Once
+	// diskMu orders disk writes against Clear, and generation counts the
+	// Clears so a write of an entry added before one is dropped.
+	diskMu     sync.Mutex
+	generation int
 }
 
 type cacheEntry struct {
 	createdAt time.Time
 	val       []byte
+	elem      *list.Element
 }
 
-func NewCache(interval time.Duration) *Cache {
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Expired   uint64
+	Entries   int
+	Bytes     int64
+	// DiskErrors counts failed disk writes, the last of which is
+	// LastDiskError.
+	DiskErrors    uint64
+	LastDiskError string
+}
+
+type Option func(*Cache)
+
+// WithDiskDir persists entries under dir so they survive restarts. Disk
+// entries expire after the same interval as in-memory ones.
+func WithDiskDir(dir string) Option {
+	return func(c *Cache) {
+		c.disk = &diskStore{dir: dir}
+	}
+}
+
+// WithMaxBytes bounds the in-memory size of cached values, evicting the
+// least recently used entries once exceeded. Zero means unbounded.
+func WithMaxBytes(n int64) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+// WithMaxEntries bounds the number of in-memory entries, evicting the least
+// recently used entries once exceeded. Zero means unbounded.
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+func NewCache(interval time.Duration, opts ...Option) *Cache {
 	c := Cache{
-		entries: map[string]cacheEntry{},
-		mu:      sync.Mutex{},
+		entries:  map[string]cacheEntry{},
+		mu:       sync.Mutex{},
+		interval: interval,
+		lru:      list.New(),
+	}
+	for _, opt := range opts {
+		opt(&c)
 	}
 	go c.reapLoop(interval)
 	return &c
@@ -31,29 +95,219 @@ func (c *Cache) Add(key string, val []byte) {
 	}
 	entry.val = append(entry.val, val...)
 	c.mu.Lock()
-	c.entries[key] = entry
+	c.store(key, entry)
+	if c.index != nil {
+		c.index[key] = entry.createdAt
+	}
+	generation := c.generation
 	c.mu.Unlock()
+	// The disk is written without holding c.mu so reads are not held up by
+	// file I/O.
+	if c.disk != nil {
+		c.writeDisk(key, entry, generation)
+	}
+}
+
+// writeDisk writes an entry added in generation to disk, unless the cache
+// has been cleared since. Failures are counted in the stats.
+func (c *Cache) writeDisk(key string, entry cacheEntry, generation int) {
+	c.diskMu.Lock()
+	defer c.diskMu.Unlock()
+	c.mu.Lock()
+	cleared := generation != c.generation
+	c.mu.Unlock()
+	if cleared {
+		return
+	}
+	if err := c.disk.write(key, entry); err != nil {
+		c.mu.Lock()
+		c.stats.DiskErrors++
+		c.stats.LastDiskError = err.Error()
+		c.mu.Unlock()
+	}
 }
 
 func (c *Cache) Get(key string) ([]byte, bool) {
 	c.mu.Lock()
 	entry, found := c.entries[key]
+	if found {
+		c.lru.MoveToFront(entry.elem)
+		c.stats.Hits++
+		c.mu.Unlock()
+		return entry.val, true
+	}
 	c.mu.Unlock()
+	if c.disk != nil {
+		entry, found = c.disk.read(key)
+		if found && time.Since(entry.createdAt) > c.interval {
+			c.disk.remove(key)
+			found = false
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if !found {
+		delete(c.index, key)
+		c.stats.Misses++
 		return nil, false
 	}
+	// Another caller may have added the key while the disk was read.
+	if current, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(current.elem)
+		entry = current
+	} else {
+		c.store(key, entry)
+	}
+	c.stats.Hits++
 	return entry.val, true
 }
 
+// Peek returns the value for key like Get, but leaves the entry's place in
+// the LRU order and the stats alone, and does not load it into memory.
+func (c *Cache) Peek(key string) ([]byte, bool) {
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	c.mu.Unlock()
+	if found {
+		return entry.val, true
+	}
+	if c.disk == nil {
+		return nil, false
+	}
+	entry, found = c.disk.read(key)
+	if !found || time.Since(entry.createdAt) > c.interval {
+		return nil, false
+	}
+	return entry.val, true
+}
+
+// Keys lists the unexpired keys starting with prefix, in memory or on disk,
+// in sorted order. The disk is only scanned on the first call.
+func (c *Cache) Keys(prefix string) []string {
+	c.indexOnce.Do(c.loadIndex)
+	cutoff := time.Now().Add(-c.interval)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	seen := map[string]bool{}
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			seen[key] = true
+		}
+	}
+	for key, createdAt := range c.index {
+		if createdAt.After(cutoff) && strings.HasPrefix(key, prefix) {
+			seen[key] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// loadIndex reads the keys on disk into c.index. Entries added while the
+// disk is scanned are in memory, so they are added too.
+func (c *Cache) loadIndex() {
+	if c.disk == nil {
+		return
+	}
+	index := c.disk.index()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		index[key] = entry.createdAt
+	}
+	c.index = index
+}
+
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	stats.Bytes = c.bytes
+	return stats
+}
+
+// Clear removes every entry from memory and from disk.
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	c.entries = map[string]cacheEntry{}
+	c.lru.Init()
+	c.bytes = 0
+	if c.index != nil {
+		c.index = map[string]time.Time{}
+	}
+	c.generation++
+	c.mu.Unlock()
+	if c.disk != nil {
+		c.diskMu.Lock()
+		defer c.diskMu.Unlock()
+		return c.disk.clear()
+	}
+	return nil
+}
+
+// DiskUsage reports the number of entries and bytes stored on disk.
+func (c *Cache) DiskUsage() (int, int64, error) {
+	if c.disk == nil {
+		return 0, 0, nil
+	}
+	return c.disk.usage()
+}
+
+// store inserts entry as the most recently used and evicts from the back of
+// the list until the cache is within its bounds. Callers hold c.mu.
+func (c *Cache) store(key string, entry cacheEntry) {
+	if old, found := c.entries[key]; found {
+		c.remove(key, old)
+	}
+	entry.elem = c.lru.PushFront(key)
+	c.entries[key] = entry
+	c.bytes += int64(len(entry.val))
+	for c.overBudget() {
+		oldest := c.lru.Back()
+		k := oldest.Value.(string)
+		c.remove(k, c.entries[k])
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) overBudget() bool {
+	if len(c.entries) == 0 {
+		return false
+	}
+	return (c.maxBytes > 0 && c.bytes > c.maxBytes) ||
+		(c.maxEntries > 0 && len(c.entries) > c.maxEntries)
+}
+
+func (c *Cache) remove(key string, entry cacheEntry) {
+	c.lru.Remove(entry.elem)
+	c.bytes -= int64(len(entry.val))
+	delete(c.entries, key)
+}
+
 func (c *Cache) reapLoop(interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	for t := range ticker.C {
+		c.mu.Lock()
 		for k, v := range c.entries {
 			if t.After(v.createdAt.Add(interval)) {
-				c.mu.Lock()
-				delete(c.entries, k)
-				c.mu.Unlock()
+				c.remove(k, v)
+				c.stats.Expired++
+			}
+		}
+		for k, createdAt := range c.index {
+			if t.After(createdAt.Add(interval)) {
+				delete(c.index, k)
 			}
 		}
+		c.mu.Unlock()
+		if c.disk != nil {
+			c.disk.reap(t.Add(-interval))
+		}
 	}
 }
@@ -1,6 +1,9 @@
 package pokecache
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 )
@@ -86,3 +89,202 @@ func TestCacheReapBeforeInterval(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestDiskSurvivesRestart(t *testing.T) {
+	k := "https://pokeapi.co/api/v2/pokemon/pikachu"
+	dir := t.TempDir()
+	c := NewCache(5*time.Second, WithDiskDir(dir))
+	c.Add(k, []byte("pikachu"))
+
+	restarted := NewCache(5*time.Second, WithDiskDir(dir))
+	val, found := restarted.Get(k)
+	if !found {
+		t.Errorf("disk entry not found after restart")
+		t.Fail()
+	}
+	if string(val) != "pikachu" {
+		t.Errorf("%s does not match pikachu", val)
+		t.Fail()
+	}
+}
+
+func TestDiskExpiredEntry(t *testing.T) {
+	k := "https://pokeapi.co/api/v2/pokemon/pikachu"
+	dir := t.TempDir()
+	c := NewCache(time.Hour, WithDiskDir(dir))
+	c.disk.write(k, cacheEntry{createdAt: time.Now().Add(-2 * time.Hour), val: []byte("old")})
+	if _, found := c.Get(k); found {
+		t.Errorf("expired disk entry should not be found")
+		t.Fail()
+	}
+	if entries, _, _ := c.DiskUsage(); entries != 0 {
+		t.Errorf("expired disk entry should have been removed")
+		t.Fail()
+	}
+}
+
+func TestDiskReapAfterInterval(t *testing.T) {
+	k := "https://pokeapi.co/api/v2/location-area?offset=40&limit=20"
+	dir := t.TempDir()
+	c := NewCache(2*time.Second, WithDiskDir(dir))
+	c.Add(k, []byte{})
+	time.Sleep(3 * time.Second)
+	if entries, _, _ := c.DiskUsage(); entries != 0 {
+		t.Errorf("disk entry should have been reaped")
+		t.Fail()
+	}
+}
+
+func TestClear(t *testing.T) {
+	k := "https://pokeapi.co/api/v2/pokemon/pikachu"
+	c := NewCache(5*time.Second, WithDiskDir(t.TempDir()))
+	c.Add(k, []byte("pikachu"))
+	if entries, size, _ := c.DiskUsage(); entries != 1 || size == 0 {
+		t.Errorf("expected one disk entry, got %d (%d bytes)", entries, size)
+		t.Fail()
+	}
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := c.Get(k); found {
+		t.Errorf("entry found after Clear")
+		t.Fail()
+	}
+}
+
+func TestClearDropsPendingWrite(t *testing.T) {
+	k := "https://pokeapi.co/api/v2/pokemon/pikachu"
+	dir := t.TempDir()
+	c := NewCache(time.Hour, WithDiskDir(dir))
+	// A write of an entry added before Clear that lands after it.
+	generation := c.generation
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	c.writeDisk(k, cacheEntry{createdAt: time.Now(), val: []byte("pikachu")}, generation)
+	if _, found := NewCache(time.Hour, WithDiskDir(dir)).Get(k); found {
+		t.Errorf("entry written to disk after Clear")
+		t.Fail()
+	}
+}
+
+func TestDiskWriteErrors(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := NewCache(time.Hour, WithDiskDir(dir))
+	c.Add("key", []byte("value"))
+	if stats := c.Stats(); stats.DiskErrors != 1 || stats.LastDiskError == "" {
+		t.Errorf("expected a disk write error, got %+v", stats)
+		t.Fail()
+	}
+}
+
+func TestLRUMaxEntries(t *testing.T) {
+	c := NewCache(5*time.Second, WithMaxEntries(2))
+	c.Add("a", []byte("a"))
+	c.Add("b", []byte("b"))
+	c.Get("a")
+	c.Add("c", []byte("c"))
+	if _, found := c.Get("b"); found {
+		t.Errorf("least recently used entry should have been evicted")
+		t.Fail()
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, found := c.Get(k); !found {
+			t.Errorf("entry %s should not have been evicted", k)
+			t.Fail()
+		}
+	}
+}
+
+func TestLRUMaxBytes(t *testing.T) {
+	c := NewCache(5*time.Second, WithMaxBytes(10))
+	c.Add("a", make([]byte, 4))
+	c.Add("b", make([]byte, 4))
+	c.Add("c", make([]byte, 4))
+	if _, found := c.Get("a"); found {
+		t.Errorf("oldest entry should have been evicted")
+		t.Fail()
+	}
+	if stats := c.Stats(); stats.Bytes != 8 || stats.Entries != 2 {
+		t.Errorf("expected 2 entries and 8 bytes, got %d and %d", stats.Entries, stats.Bytes)
+		t.Fail()
+	}
+}
+
+func TestStats(t *testing.T) {
+	c := NewCache(5*time.Second, WithMaxEntries(1))
+	c.Add("a", []byte("a"))
+	c.Get("a")
+	c.Get("missing")
+	c.Add("b", []byte("b"))
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+		t.Fail()
+	}
+}
+
+func TestAddReplacesEntry(t *testing.T) {
+	c := NewCache(5 * time.Second)
+	c.Add("a", []byte("first"))
+	c.Add("a", []byte("second"))
+	if stats := c.Stats(); stats.Entries != 1 || stats.Bytes != int64(len("second")) {
+		t.Errorf("replacing an entry should not grow the cache: %+v", stats)
+		t.Fail()
+	}
+}
+
+func TestKeys(t *testing.T) {
+	dir := t.TempDir()
+	c := NewCache(time.Hour, WithDiskDir(dir))
+	c.Add("https://pokeapi.co/api/v2/location-area/canalave-city-area", []byte("{}"))
+	c.Add("https://pokeapi.co/api/v2/pokemon/pikachu", []byte("{}"))
+	c.disk.write("https://pokeapi.co/api/v2/location-area/old-area", cacheEntry{createdAt: time.Now().Add(-2 * time.Hour), val: []byte("{}")})
+
+	restarted := NewCache(time.Hour, WithDiskDir(dir))
+	restarted.Add("https://pokeapi.co/api/v2/location-area/eterna-forest-area", []byte("{}"))
+	keys := restarted.Keys("https://pokeapi.co/api/v2/location-area/")
+	want := []string{
+		"https://pokeapi.co/api/v2/location-area/canalave-city-area",
+		"https://pokeapi.co/api/v2/location-area/eterna-forest-area",
+	}
+	if !slices.Equal(keys, want) {
+		t.Errorf("unexpected keys %v, want %v", keys, want)
+		t.Fail()
+	}
+
+	// Once the index is loaded, new entries are listed without rescanning.
+	restarted.Add("https://pokeapi.co/api/v2/location-area/sinnoh-route-201-area", []byte("{}"))
+	if keys := restarted.Keys("https://pokeapi.co/api/v2/location-area/sinnoh"); len(keys) != 1 {
+		t.Errorf("expected the added key, got %v", keys)
+		t.Fail()
+	}
+}
+
+func TestPeek(t *testing.T) {
+	dir := t.TempDir()
+	c := NewCache(time.Hour, WithDiskDir(dir), WithMaxEntries(2))
+	c.Add("a", []byte("1"))
+	c.Add("b", []byte("2"))
+	if val, ok := c.Peek("a"); !ok || string(val) != "1" {
+		t.Errorf("expected to peek at a, got %q", val)
+		t.Fail()
+	}
+	// Peeking did not make a recently used, so it is evicted first.
+	c.Add("c", []byte("3"))
+	if _, ok := c.entries["a"]; ok {
+		t.Errorf("expected a to be evicted after peeking at it")
+		t.Fail()
+	}
+	if val, ok := c.Peek("a"); !ok || string(val) != "1" {
+		t.Errorf("expected to peek at a on disk, got %q", val)
+		t.Fail()
+	}
+	if _, ok := c.entries["a"]; ok || c.Stats().Hits != 0 {
+		t.Errorf("peeking on disk should not load a or count a hit")
+		t.Fail()
+	}
+}
@@ -0,0 +1,178 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	metaExt = ".json"
+	dataExt = ".bin"
+)
+
+// diskStore keeps each entry as a pair of files named by the SHA-256 of its
+// key: the raw value and a small metadata document. It has no state besides
+// the files, which are replaced atomically, so it is used without holding
+// Cache.mu; Cache.diskMu orders writes against clear.
+type diskStore struct {
+	dir string
+}
+
+type diskMeta struct {
+	Key       string    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (d *diskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
+
+func (d *diskStore) write(key string, entry cacheEntry) error {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+	meta, err := json.Marshal(diskMeta{Key: key, CreatedAt: entry.createdAt})
+	if err != nil {
+		return fmt.Errorf("error encoding cache metadata: %w", err)
+	}
+	base := d.path(key)
+	if err := writeFile(base+dataExt, entry.val); err != nil {
+		return fmt.Errorf("error writing cache file: %w", err)
+	}
+	if err := writeFile(base+metaExt, meta); err != nil {
+		os.Remove(base + dataExt)
+		return fmt.Errorf("error writing cache file: %w", err)
+	}
+	return nil
+}
+
+func (d *diskStore) read(key string) (cacheEntry, bool) {
+	base := d.path(key)
+	data, err := os.ReadFile(base + metaExt)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var meta diskMeta
+	if err := json.Unmarshal(data, &meta); err != nil || meta.Key != key {
+		return cacheEntry{}, false
+	}
+	val, err := os.ReadFile(base + dataExt)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	return cacheEntry{createdAt: meta.CreatedAt, val: val}, true
+}
+
+func (d *diskStore) remove(key string) {
+	base := d.path(key)
+	os.Remove(base + metaExt)
+	os.Remove(base + dataExt)
+}
+
+func (d *diskStore) reap(cutoff time.Time) {
+	metas, err := filepath.Glob(filepath.Join(d.dir, "*"+metaExt))
+	if err != nil {
+		return
+	}
+	for _, name := range metas {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		var meta diskMeta
+		if err := json.Unmarshal(data, &meta); err != nil || meta.CreatedAt.Before(cutoff) {
+			base := strings.TrimSuffix(name, metaExt)
+			os.Remove(base + metaExt)
+			os.Remove(base + dataExt)
+		}
+	}
+}
+
+// index returns when each entry was created, by key.
+func (d *diskStore) index() map[string]time.Time {
+	index := map[string]time.Time{}
+	metas, err := filepath.Glob(filepath.Join(d.dir, "*"+metaExt))
+	if err != nil {
+		return index
+	}
+	for _, name := range metas {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		var meta diskMeta
+		if err := json.Unmarshal(data, &meta); err == nil {
+			index[meta.Key] = meta.CreatedAt
+		}
+	}
+	return index
+}
+
+func (d *diskStore) clear() error {
+	files, err := d.files()
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error removing cache file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (d *diskStore) usage() (int, int64, error) {
+	files, err := d.files()
+	if err != nil {
+		return 0, 0, err
+	}
+	var entries int
+	var size int64
+	for _, name := range files {
+		info, err := os.Stat(name)
+		if err != nil {
+			continue
+		}
+		if strings.HasSuffix(name, metaExt) {
+			entries++
+		}
+		size += info.Size()
+	}
+	return entries, size, nil
+}
+
+func (d *diskStore) files() ([]string, error) {
+	var files []string
+	for _, ext := range []string{metaExt, dataExt} {
+		matches, err := filepath.Glob(filepath.Join(d.dir, "*"+ext))
+		if err != nil {
+			return nil, fmt.Errorf("error listing cache files: %w", err)
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+func writeFile(name string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
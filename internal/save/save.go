@@ -0,0 +1,230 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/faust-m/pokedexcli/internal/trainer"
+)
+
+const (
+	CurrentVersion = 5
+	appDir         = "pokedexcli"
+	fileName       = "save.json"
+)
+
+var ErrNewerVersion = errors.New("save file was written by a newer version")
+
+type File struct {
+	Version  int              `json:"version"`
+	Trainer  *trainer.Trainer `json:"trainer"`
+	Settings Settings         `json:"settings"`
+}
+
+// Settings are player preferences kept with the save. An empty game
+// version means data from every game is used.
+type Settings struct {
+	GameVersion  string `json:"game_version"`
+	VersionGroup string `json:"version_group"`
+	Generation   string `json:"generation"`
+	Format       string `json:"format,omitempty"`
+}
+
+// migrations[n] upgrades a raw version n save to version n+1.
+var migrations = map[int]func(map[string]json.RawMessage) error{
+	1: migrateV1,
+	2: migrateV2,
+	3: migrateV3,
+	4: migrateV4,
+}
+
+func New() File {
+	return File{
+		Version: CurrentVersion,
+		Trainer: trainer.New(),
+	}
+}
+
+// migrateV1 moves the version 1 pokedex, a map of caught Pokemon keyed by
+// name, into the party and boxes of a trainer.
+func migrateV1(raw map[string]json.RawMessage) error {
+	var pokedex map[string]struct {
+		Name    string `json:"name"`
+		Species struct {
+			Name string `json:"name"`
+		} `json:"species"`
+	}
+	if err := json.Unmarshal(raw["pokedex"], &pokedex); err != nil {
+		return fmt.Errorf("error reading pokedex: %w", err)
+	}
+	t := trainer.New()
+	for _, name := range slices.Sorted(maps.Keys(pokedex)) {
+		species := pokedex[name].Species.Name
+		if species == "" {
+			species = name
+		}
+		t.Add(name, species)
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("error serializing trainer: %w", err)
+	}
+	delete(raw, "pokedex")
+	raw["trainer"] = data
+	return nil
+}
+
+// migrateV2 gives Pokemon caught before levels were tracked level 5 and a
+// neutral nature. Their experience is topped up to the level's minimum the
+// next time they gain any.
+func migrateV2(raw map[string]json.RawMessage) error {
+	t := trainer.New()
+	if data, ok := raw["trainer"]; ok {
+		if err := json.Unmarshal(data, t); err != nil {
+			return fmt.Errorf("error reading trainer: %w", err)
+		}
+	}
+	for _, p := range t.All() {
+		if p.Level == 0 {
+			p.Level = 5
+		}
+		if p.Nature == "" {
+			p.Nature = "hardy"
+		}
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("error serializing trainer: %w", err)
+	}
+	raw["trainer"] = data
+	return nil
+}
+
+// migrateV3 gives trainers from before items existed the starting money and
+// Poke Balls.
+func migrateV3(raw map[string]json.RawMessage) error {
+	t := trainer.New()
+	if data, ok := raw["trainer"]; ok {
+		if err := json.Unmarshal(data, t); err != nil {
+			return fmt.Errorf("error reading trainer: %w", err)
+		}
+	}
+	t.Money = trainer.StartingMoney
+	t.Bag = map[string]int{"poke-ball": trainer.StartingBalls}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("error serializing trainer: %w", err)
+	}
+	raw["trainer"] = data
+	return nil
+}
+
+// migrateV4 gives Pokemon caught before friendship was tracked the default
+// friendship, as their species' base happiness is not known offline.
+func migrateV4(raw map[string]json.RawMessage) error {
+	t := trainer.New()
+	if data, ok := raw["trainer"]; ok {
+		if err := json.Unmarshal(data, t); err != nil {
+			return fmt.Errorf("error reading trainer: %w", err)
+		}
+	}
+	for _, p := range t.All() {
+		if p.Friendship == 0 {
+			p.Friendship = trainer.DefaultFriendship
+		}
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("error serializing trainer: %w", err)
+	}
+	raw["trainer"] = data
+	return nil
+}
+
+func DefaultPath() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("error finding home directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, appDir, fileName), nil
+}
+
+func Load(path string) (File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, fmt.Errorf("error reading save file: %w", err)
+	}
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return File{}, fmt.Errorf("error deserializing save file: %w", err)
+	}
+	var version int
+	if err := json.Unmarshal(raw["version"], &version); err != nil {
+		return File{}, fmt.Errorf("error reading save file version: %w", err)
+	}
+	if version > CurrentVersion {
+		return File{}, fmt.Errorf("%w: %d > %d", ErrNewerVersion, version, CurrentVersion)
+	}
+	if version < 1 {
+		return File{}, fmt.Errorf("invalid save file version: %d", version)
+	}
+	for ; version < CurrentVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return File{}, fmt.Errorf("no migration from save file version %d", version)
+		}
+		if err := migrate(raw); err != nil {
+			return File{}, fmt.Errorf("error migrating save file from version %d: %w", version, err)
+		}
+	}
+	raw["version"] = json.RawMessage(fmt.Sprint(CurrentVersion))
+
+	data, err = json.Marshal(raw)
+	if err != nil {
+		return File{}, fmt.Errorf("error serializing migrated save file: %w", err)
+	}
+	f := New()
+	if err := json.Unmarshal(data, &f); err != nil {
+		return File{}, fmt.Errorf("error deserializing save file: %w", err)
+	}
+	if f.Trainer == nil {
+		f.Trainer = trainer.New()
+	}
+	return f, nil
+}
+
+func Write(path string, f File) error {
+	f.Version = CurrentVersion
+	data, err := json.Marshal(&f)
+	if err != nil {
+		return fmt.Errorf("error serializing save file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating save directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), fileName+".*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary save file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing save file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error closing save file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error replacing save file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package save
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/faust-m/pokedexcli/internal/trainer"
+)
+
+func TestWriteLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "save.json")
+	f := New()
+	f.Trainer.Add("pikachu", "pikachu")
+	f.Settings.GameVersion = "platinum"
+	if err := Write(path, f); err != nil {
+		t.Fatalf("error writing save file: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("error loading save file: %v", err)
+	}
+	if loaded.Version != CurrentVersion {
+		t.Errorf("expected version %d, got %d", CurrentVersion, loaded.Version)
+	}
+	if _, _, _, err := loaded.Trainer.Find("pikachu"); err != nil {
+		t.Errorf("pikachu was not restored from save file")
+	}
+	if loaded.Settings.GameVersion != "platinum" {
+		t.Errorf("expected game version platinum, got %q", loaded.Settings.GameVersion)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "save.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestLoadNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte(`{"version": 999, "trainer": {}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); !errors.Is(err, ErrNewerVersion) {
+		t.Errorf("expected ErrNewerVersion, got %v", err)
+	}
+}
+
+func TestLoadIgnoresUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	data := `{"version": 2, "trainer": {"party": [{"id": 1, "name": "eevee", "species": "eevee"}], "next_id": 2}, "future_field": [1, 2, 3]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("error loading save file: %v", err)
+	}
+	if _, _, _, err := f.Trainer.Find("eevee"); err != nil {
+		t.Errorf("eevee was not restored from save file")
+	}
+}
+
+func TestMigrateV1(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	data := `{"version": 1, "pokedex": {"pikachu": {"name": "pikachu", "species": {"name": "pikachu"}}, "eevee": {"name": "eevee"}}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("error loading version 1 save file: %v", err)
+	}
+	if len(f.Trainer.Party) != 2 {
+		t.Fatalf("expected 2 party members, got %d", len(f.Trainer.Party))
+	}
+	if f.Trainer.Party[0].Name != "eevee" || f.Trainer.Party[0].Species != "eevee" {
+		t.Errorf("unexpected first party member: %+v", f.Trainer.Party[0])
+	}
+	if !f.Trainer.Pokedex.Caught["pikachu"] {
+		t.Errorf("pikachu was not marked caught")
+	}
+	if f.Trainer.NextID != 3 {
+		t.Errorf("expected next ID 3, got %d", f.Trainer.NextID)
+	}
+}
+
+func TestMigrateV2(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	data := `{"version": 2, "trainer": {"party": [{"id": 1, "name": "eevee", "species": "eevee"}], "next_id": 2}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("error loading version 2 save file: %v", err)
+	}
+	if p := f.Trainer.Party[0]; p.Level != 5 || p.Nature != "hardy" {
+		t.Errorf("expected level 5 with a hardy nature, got %+v", p)
+	}
+}
+
+func TestMigrateV3(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	data := `{"version": 3, "trainer": {"party": [{"id": 1, "name": "eevee", "species": "eevee", "level": 12}], "next_id": 2}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("error loading version 3 save file: %v", err)
+	}
+	if f.Trainer.Money != trainer.StartingMoney || f.Trainer.Bag["poke-ball"] != trainer.StartingBalls {
+		t.Errorf("expected starting money and balls, got %d and %v", f.Trainer.Money, f.Trainer.Bag)
+	}
+	if f.Trainer.Party[0].Level != 12 {
+		t.Errorf("migration changed eevee's level to %d", f.Trainer.Party[0].Level)
+	}
+}
+
+func TestMigrateV4(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	data := `{"version": 4, "trainer": {"party": [{"id": 1, "name": "eevee", "species": "eevee"}, {"id": 2, "name": "pikachu", "species": "pikachu", "friendship": 120}], "next_id": 3}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("error loading version 4 save file: %v", err)
+	}
+	if p := f.Trainer.Party[0]; p.Friendship != trainer.DefaultFriendship {
+		t.Errorf("expected eevee to get the default friendship, got %d", p.Friendship)
+	}
+	if p := f.Trainer.Party[1]; p.Friendship != 120 {
+		t.Errorf("migration changed pikachu's friendship to %d", p.Friendship)
+	}
+}
+
+func TestDefaultPathXDG(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/tmp/xdg")
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join("/tmp/xdg", "pokedexcli", "save.json") {
+		t.Errorf("unexpected default path: %s", path)
+	}
+}
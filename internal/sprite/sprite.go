@@ -0,0 +1,196 @@
+package sprite
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+)
+
+// Mode is the kind of output the terminal can display.
+type Mode int
+
+const (
+	ASCII Mode = iota
+	Color256
+	TrueColor
+)
+
+const (
+	// upperHalf and lowerHalf draw two pixels per character cell, one in
+	// the foreground color and one in the background color.
+	upperHalf = "▀"
+	lowerHalf = "▄"
+	reset     = "\x1b[0m"
+
+	// ramp shades opaque pixels from dark to light in ASCII mode.
+	ramp = ".:-=+*#%@"
+)
+
+// DetectMode picks the richest mode the terminal described by the
+// environment supports. NO_COLOR and dumb or missing terminals get ASCII.
+func DetectMode(getenv func(string) string) Mode {
+	term := getenv("TERM")
+	switch {
+	case getenv("NO_COLOR") != "", term == "", term == "dumb":
+		return ASCII
+	case getenv("COLORTERM") == "truecolor", getenv("COLORTERM") == "24bit":
+		return TrueColor
+	default:
+		return Color256
+	}
+}
+
+// pixel is a straight (not premultiplied) 8-bit color. Transparent pixels
+// are not drawn.
+type pixel struct {
+	r, g, b     uint8
+	transparent bool
+}
+
+// Render draws img to fit within width columns and height lines, two pixel
+// rows per line. Transparent borders are cropped and the image is only ever
+// shrunk. A zero width or height is unbounded.
+func Render(w io.Writer, img image.Image, width, height int, mode Mode) error {
+	pixels := scale(img, crop(img), width, height*2)
+	bw := bufio.NewWriter(w)
+	for y := 0; y < len(pixels); y += 2 {
+		var line strings.Builder
+		for x := range pixels[y] {
+			top := pixels[y][x]
+			bottom := pixel{transparent: true}
+			if y+1 < len(pixels) {
+				bottom = pixels[y+1][x]
+			}
+			line.WriteString(cell(top, bottom, mode))
+		}
+		out := line.String()
+		if mode == ASCII {
+			out = strings.TrimRight(out, " ")
+		} else {
+			out += reset
+		}
+		if _, err := fmt.Fprintln(bw, out); err != nil {
+			return fmt.Errorf("error writing sprite: %w", err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("error writing sprite: %w", err)
+	}
+	return nil
+}
+
+func cell(top, bottom pixel, mode Mode) string {
+	switch {
+	case top.transparent && bottom.transparent:
+		if mode == ASCII {
+			return " "
+		}
+		return reset + " "
+	case mode == ASCII:
+		return string(ramp[shade(top, bottom)*(len(ramp)-1)/255])
+	case bottom.transparent:
+		return reset + fg(top, mode) + upperHalf
+	case top.transparent:
+		return reset + fg(bottom, mode) + lowerHalf
+	default:
+		return fg(top, mode) + bg(bottom, mode) + upperHalf
+	}
+}
+
+// shade is the average luma of the opaque pixels in a cell, from 0 to 255.
+func shade(pixels ...pixel) int {
+	total, n := 0, 0
+	for _, p := range pixels {
+		if !p.transparent {
+			total += (299*int(p.r) + 587*int(p.g) + 114*int(p.b)) / 1000
+			n++
+		}
+	}
+	return total / n
+}
+
+func fg(p pixel, mode Mode) string {
+	if mode == TrueColor {
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", p.r, p.g, p.b)
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm", index256(p))
+}
+
+func bg(p pixel, mode Mode) string {
+	if mode == TrueColor {
+		return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", p.r, p.g, p.b)
+	}
+	return fmt.Sprintf("\x1b[48;5;%dm", index256(p))
+}
+
+// index256 maps a color to the nearest entry of the 6x6x6 color cube in the
+// 256-color palette.
+func index256(p pixel) int {
+	level := func(v uint8) int {
+		return (int(v)*5 + 127) / 255
+	}
+	return 16 + 36*level(p.r) + 6*level(p.g) + level(p.b)
+}
+
+// crop returns the bounds of the non-transparent pixels of img.
+func crop(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	var r image.Rectangle
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a > 0 {
+				r = r.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return r
+}
+
+// scale shrinks the r part of img to fit within width by height pixels,
+// averaging the pixels each output pixel covers.
+func scale(img image.Image, r image.Rectangle, width, height int) [][]pixel {
+	if r.Empty() {
+		return nil
+	}
+	factor := 1.0
+	if width > 0 && r.Dx() > width {
+		factor = float64(r.Dx()) / float64(width)
+	}
+	if height > 0 && float64(r.Dy())/factor > float64(height) {
+		factor = float64(r.Dy()) / float64(height)
+	}
+	cols := max(int(float64(r.Dx())/factor), 1)
+	rows := max(int(float64(r.Dy())/factor), 1)
+	pixels := make([][]pixel, rows)
+	for y := range pixels {
+		pixels[y] = make([]pixel, cols)
+		y0 := r.Min.Y + y*r.Dy()/rows
+		y1 := max(r.Min.Y+(y+1)*r.Dy()/rows, y0+1)
+		for x := range pixels[y] {
+			x0 := r.Min.X + x*r.Dx()/cols
+			x1 := max(r.Min.X+(x+1)*r.Dx()/cols, x0+1)
+			pixels[y][x] = average(img, image.Rect(x0, y0, x1, y1))
+		}
+	}
+	return pixels
+}
+
+// average blends the pixels in r, which is transparent if it is mostly
+// transparent.
+func average(img image.Image, r image.Rectangle) pixel {
+	var sr, sg, sb, sa, n uint64
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			// RGBA returns alpha-premultiplied 16-bit channels.
+			cr, cg, cb, ca := img.At(x, y).RGBA()
+			sr, sg, sb, sa = sr+uint64(cr), sg+uint64(cg), sb+uint64(cb), sa+uint64(ca)
+			n++
+		}
+	}
+	if sa*2 < n*0xffff {
+		return pixel{transparent: true}
+	}
+	return pixel{r: uint8(sr * 0xff / sa), g: uint8(sg * 0xff / sa), b: uint8(sb * 0xff / sa)}
+}
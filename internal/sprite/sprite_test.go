@@ -0,0 +1,99 @@
+package sprite
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// testImage is a 10x10 transparent image with a 4x6 opaque block: red on
+// top and white below.
+func testImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for y := 2; y < 8; y++ {
+		for x := 3; x < 7; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if y >= 5 {
+				c = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDetectMode(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want Mode
+	}{
+		{map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, TrueColor},
+		{map[string]string{"TERM": "xterm-256color"}, Color256},
+		{map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor", "NO_COLOR": "1"}, ASCII},
+		{map[string]string{"TERM": "dumb"}, ASCII},
+		{map[string]string{}, ASCII},
+	}
+	for _, tt := range tests {
+		getenv := func(key string) string { return tt.env[key] }
+		if got := DetectMode(getenv); got != tt.want {
+			t.Errorf("DetectMode(%v) = %v, want %v", tt.env, got, tt.want)
+			t.Fail()
+		}
+	}
+}
+
+func TestRenderCrops(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, testImage(), 0, 0, ASCII); err != nil {
+		t.Fatal(err)
+	}
+	// The 4x6 block becomes three lines of four characters, red rows shaded
+	// darker than white ones.
+	want := "----\n****\n@@@@\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected ASCII rendering:\n%s\nwant:\n%s", got, want)
+		t.Fail()
+	}
+}
+
+func TestRenderColor(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, testImage(), 2, 0, TrueColor); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	// Shrinking to two columns halves the height to three pixel rows.
+	if len(lines) != 2 || strings.Count(lines[0], upperHalf) != 2 || strings.Count(lines[1], upperHalf) != 2 {
+		t.Fatalf("unexpected truecolor rendering: %q", lines)
+	}
+	if !strings.Contains(lines[0], "\x1b[38;2;255;0;0m") || !strings.Contains(lines[1], "\x1b[38;2;255;255;255m") {
+		t.Errorf("expected red on the first line and white on the second, got %q", lines)
+		t.Fail()
+	}
+	if !strings.HasSuffix(lines[1], reset) {
+		t.Errorf("expected lines to reset colors, got %q", lines[1])
+		t.Fail()
+	}
+
+	buf.Reset()
+	if err := Render(&buf, testImage(), 0, 0, Color256); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "\x1b[38;5;196m") || !strings.Contains(buf.String(), "\x1b[48;5;231m") {
+		t.Errorf("expected 256-color red and white, got %q", buf.String())
+		t.Fail()
+	}
+}
+
+func TestRenderTransparent(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Render(&buf, image.NewNRGBA(image.Rect(0, 0, 4, 4)), 0, 0, TrueColor); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing for a transparent image, got %q", buf.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package sprite
+
+import "os"
+
+// TerminalSize is not supported on this platform, so it always reports
+// false.
+func TerminalSize(f *os.File) (cols, lines int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,22 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package sprite
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// TerminalSize returns the number of columns and lines of the terminal f is
+// connected to, and false if it is not a terminal.
+func TerminalSize(f *os.File) (cols, lines int, ok bool) {
+	var ws struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 || ws.Col == 0 {
+		return 0, 0, false
+	}
+	return int(ws.Col), int(ws.Row), true
+}
@@ -0,0 +1,31 @@
+package stats
+
+import "github.com/faust-m/pokedexcli/internal/pokeapi"
+
+// Experience returns the total experience needed to reach level on a growth
+// rate.
+func Experience(rate pokeapi.GrowthRate, level int) int {
+	for _, l := range rate.Levels {
+		if l.Level == level {
+			return l.Experience
+		}
+	}
+	return 0
+}
+
+// Level returns the highest level reached with exp total experience.
+func Level(rate pokeapi.GrowthRate, exp int) int {
+	level := 1
+	for _, l := range rate.Levels {
+		if l.Experience <= exp && l.Level > level {
+			level = l.Level
+		}
+	}
+	return min(level, MaxLevel)
+}
+
+// ExpYield is the experience gained for defeating a wild Pokemon, using the
+// flat formula of Generations I to IV without trainer or item bonuses.
+func ExpYield(baseExperience, level int) int {
+	return max(baseExperience*level/7, 1)
+}
@@ -0,0 +1,64 @@
+package stats
+
+import "math/rand"
+
+// Nature raises one stat by 10% and lowers another by 10%. Neutral natures
+// have neither.
+type Nature struct {
+	Name      string
+	Increased string
+	Decreased string
+}
+
+var Natures = []Nature{
+	{"hardy", "", ""},
+	{"lonely", "attack", "defense"},
+	{"brave", "attack", "speed"},
+	{"adamant", "attack", "special-attack"},
+	{"naughty", "attack", "special-defense"},
+	{"bold", "defense", "attack"},
+	{"docile", "", ""},
+	{"relaxed", "defense", "speed"},
+	{"impish", "defense", "special-attack"},
+	{"lax", "defense", "special-defense"},
+	{"timid", "speed", "attack"},
+	{"hasty", "speed", "defense"},
+	{"serious", "", ""},
+	{"jolly", "speed", "special-attack"},
+	{"naive", "speed", "special-defense"},
+	{"modest", "special-attack", "attack"},
+	{"mild", "special-attack", "defense"},
+	{"quiet", "special-attack", "speed"},
+	{"bashful", "", ""},
+	{"rash", "special-attack", "special-defense"},
+	{"calm", "special-defense", "attack"},
+	{"gentle", "special-defense", "defense"},
+	{"sassy", "special-defense", "speed"},
+	{"careful", "special-defense", "special-attack"},
+	{"quirky", "", ""},
+}
+
+// NatureByName looks up a nature, returning the neutral hardy nature and
+// false if it is unknown.
+func NatureByName(name string) (Nature, bool) {
+	for _, n := range Natures {
+		if n.Name == name {
+			return n, true
+		}
+	}
+	return Natures[0], false
+}
+
+func RandomNature(rng *rand.Rand) Nature {
+	return Natures[rng.Intn(len(Natures))]
+}
+
+func (n Nature) apply(stat string, v int) int {
+	switch stat {
+	case n.Increased:
+		return v * 11 / 10
+	case n.Decreased:
+		return v * 9 / 10
+	}
+	return v
+}
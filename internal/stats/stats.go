@@ -0,0 +1,113 @@
+package stats
+
+import (
+	"math/rand"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+const (
+	MaxIV      = 31
+	MaxEV      = 252
+	MaxTotalEV = 510
+	MaxLevel   = 100
+)
+
+// Names lists the PokeAPI stat names in the order they are displayed.
+var Names = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+type Stats struct {
+	HP             int `json:"hp"`
+	Attack         int `json:"attack"`
+	Defense        int `json:"defense"`
+	SpecialAttack  int `json:"special-attack"`
+	SpecialDefense int `json:"special-defense"`
+	Speed          int `json:"speed"`
+}
+
+func (s *Stats) field(name string) *int {
+	switch name {
+	case "hp":
+		return &s.HP
+	case "attack":
+		return &s.Attack
+	case "defense":
+		return &s.Defense
+	case "special-attack":
+		return &s.SpecialAttack
+	case "special-defense":
+		return &s.SpecialDefense
+	case "speed":
+		return &s.Speed
+	}
+	return nil
+}
+
+// Get returns the stat with the given PokeAPI name, or 0 if it is unknown.
+func (s Stats) Get(name string) int {
+	if f := s.field(name); f != nil {
+		return *f
+	}
+	return 0
+}
+
+func (s Stats) Total() int {
+	return s.HP + s.Attack + s.Defense + s.SpecialAttack + s.SpecialDefense + s.Speed
+}
+
+// Base returns a Pokemon's base stats.
+func Base(p pokeapi.Pokemon) Stats {
+	var s Stats
+	for _, stat := range p.Stats {
+		if f := s.field(stat.Stat.Name); f != nil {
+			*f = stat.BaseStat
+		}
+	}
+	return s
+}
+
+// Effort returns the EVs awarded for defeating a Pokemon.
+func Effort(p pokeapi.Pokemon) Stats {
+	var s Stats
+	for _, stat := range p.Stats {
+		if f := s.field(stat.Stat.Name); f != nil {
+			*f = stat.Effort
+		}
+	}
+	return s
+}
+
+// RandomIVs rolls each individual value uniformly from 0 to 31.
+func RandomIVs(rng *rand.Rand) Stats {
+	var s Stats
+	for _, name := range Names {
+		*s.field(name) = rng.Intn(MaxIV + 1)
+	}
+	return s
+}
+
+// AddEVs adds gained effort values to evs, capping each stat at 252 and the
+// total at 510.
+func AddEVs(evs, gained Stats) Stats {
+	for _, name := range Names {
+		room := min(MaxEV-evs.Get(name), MaxTotalEV-evs.Total())
+		*evs.field(name) += max(min(gained.Get(name), room), 0)
+	}
+	return evs
+}
+
+// Calc computes actual stats at level with the formulas used since
+// Generation III.
+func Calc(base, ivs, evs Stats, level int, nature Nature) Stats {
+	var s Stats
+	for _, name := range Names {
+		v := (2*base.Get(name) + ivs.Get(name) + evs.Get(name)/4) * level / 100
+		if name == "hp" {
+			v += level + 10
+		} else {
+			v = nature.apply(name, v+5)
+		}
+		*s.field(name) = v
+	}
+	return s
+}
@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+func TestCalc(t *testing.T) {
+	// The level 78 Adamant Garchomp worked example from Bulbapedia.
+	base := Stats{HP: 108, Attack: 130, Defense: 95, SpecialAttack: 80, SpecialDefense: 85, Speed: 102}
+	ivs := Stats{HP: 24, Attack: 12, Defense: 30, SpecialAttack: 16, SpecialDefense: 23, Speed: 5}
+	evs := Stats{HP: 74, Attack: 190, Defense: 91, SpecialAttack: 48, SpecialDefense: 84, Speed: 23}
+	adamant, _ := NatureByName("adamant")
+	want := Stats{HP: 289, Attack: 278, Defense: 193, SpecialAttack: 135, SpecialDefense: 171, Speed: 171}
+	if got := Calc(base, ivs, evs, 78, adamant); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+		t.Fail()
+	}
+}
+
+func TestNatureByName(t *testing.T) {
+	if len(Natures) != 25 {
+		t.Errorf("expected 25 natures, got %d", len(Natures))
+		t.Fail()
+	}
+	n, ok := NatureByName("missing")
+	if ok || n.Name != "hardy" {
+		t.Errorf("unknown natures should fall back to hardy, got %+v", n)
+		t.Fail()
+	}
+}
+
+func TestRandomIVs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		ivs := RandomIVs(rng)
+		for _, name := range Names {
+			if v := ivs.Get(name); v < 0 || v > MaxIV {
+				t.Fatalf("%s IV out of range: %d", name, v)
+			}
+		}
+	}
+}
+
+func TestAddEVs(t *testing.T) {
+	evs := AddEVs(Stats{Attack: 250}, Stats{Attack: 3, Speed: 2})
+	if evs.Attack != MaxEV || evs.Speed != 2 {
+		t.Errorf("expected attack capped at %d, got %+v", MaxEV, evs)
+		t.Fail()
+	}
+	evs = AddEVs(Stats{HP: 252, Attack: 252, Defense: 5}, Stats{Speed: 3})
+	if evs.Total() != MaxTotalEV {
+		t.Errorf("expected total capped at %d, got %d", MaxTotalEV, evs.Total())
+		t.Fail()
+	}
+}
+
+func TestLevel(t *testing.T) {
+	var rate pokeapi.GrowthRate
+	for level := 1; level <= MaxLevel; level++ {
+		exp := level * level * level
+		if level == 1 {
+			exp = 0
+		}
+		rate.Levels = append(rate.Levels, struct {
+			Level      int `json:"level"`
+			Experience int `json:"experience"`
+		}{level, exp})
+	}
+	cases := []struct {
+		exp, level int
+	}{
+		{0, 1},
+		{124, 4},
+		{125, 5},
+		{2_000_000, 100},
+	}
+	for _, c := range cases {
+		if got := Level(rate, c.exp); got != c.level {
+			t.Errorf("expected level %d at %d exp, got %d", c.level, c.exp, got)
+			t.Fail()
+		}
+	}
+	if exp := Experience(rate, 10); exp != 1000 {
+		t.Errorf("expected 1000 exp for level 10, got %d", exp)
+		t.Fail()
+	}
+}
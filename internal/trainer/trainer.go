@@ -0,0 +1,323 @@
+package trainer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/faust-m/pokedexcli/internal/stats"
+)
+
+const (
+	PartySize = 6
+	BoxSize   = 30
+
+	// StartingMoney and StartingBalls are what a new trainer sets out with.
+	StartingMoney = 3000
+	StartingBalls = 10
+
+	// MaxFriendship is the most friendship a Pokemon can have, and
+	// DefaultFriendship the base happiness of most species, used when a
+	// Pokemon's own is unknown.
+	MaxFriendship     = 255
+	DefaultFriendship = 70
+)
+
+var (
+	ErrNotFound       = errors.New("no such Pokemon")
+	ErrPartyFull      = errors.New("your party is full")
+	ErrLastInParty    = errors.New("you cannot leave your party empty")
+	ErrNoItem         = errors.New("you do not have enough of that item")
+	ErrNotEnoughMoney = errors.New("you do not have enough money")
+	ErrNegativeAmount = errors.New("amount cannot be negative")
+)
+
+// Pokemon is an individual caught Pokemon. Name is the PokeAPI pokemon
+// resource and Species its species, which differ for alternate forms.
+// Experience is the total gained, as used by the species growth rate.
+// Friendship starts at the species' base happiness and grows as the Pokemon
+// battles and levels up.
+type Pokemon struct {
+	ID         int         `json:"id"`
+	Name       string      `json:"name"`
+	Species    string      `json:"species"`
+	CaughtAt   time.Time   `json:"caught_at"`
+	Level      int         `json:"level"`
+	Experience int         `json:"experience"`
+	Friendship int         `json:"friendship"`
+	Nature     string      `json:"nature"`
+	Ability    string      `json:"ability,omitempty"`
+	IVs        stats.Stats `json:"ivs"`
+	EVs        stats.Stats `json:"evs"`
+	Moves      []string    `json:"moves"`
+}
+
+func (p *Pokemon) String() string {
+	return fmt.Sprintf("#%d %s", p.ID, p.Name)
+}
+
+// Befriend raises the Pokemon's friendship by n, up to MaxFriendship.
+func (p *Pokemon) Befriend(n int) {
+	p.Friendship = min(p.Friendship+n, MaxFriendship)
+}
+
+// Stats computes the Pokemon's actual stats from its species' base stats.
+func (p *Pokemon) Stats(base stats.Stats) stats.Stats {
+	nature, _ := stats.NatureByName(p.Nature)
+	return stats.Calc(base, p.IVs, p.EVs, p.Level, nature)
+}
+
+// Pokedex records which Pokemon the trainer has seen and caught.
+type Pokedex struct {
+	Seen   map[string]bool `json:"seen"`
+	Caught map[string]bool `json:"caught"`
+}
+
+// Position is the region and location the trainer is currently in. It is
+// empty until the trainer first travels.
+type Position struct {
+	Region   string `json:"region"`
+	Location string `json:"location"`
+}
+
+// Trainer is the player's progress. Bag holds item counts keyed by PokeAPI
+// item name.
+type Trainer struct {
+	Pokedex  Pokedex        `json:"pokedex"`
+	Party    []*Pokemon     `json:"party"`
+	Boxes    [][]*Pokemon   `json:"boxes"`
+	NextID   int            `json:"next_id"`
+	Position Position       `json:"position"`
+	Bag      map[string]int `json:"bag"`
+	Money    int            `json:"money"`
+}
+
+// New returns a trainer with starting money and Poke Balls.
+func New() *Trainer {
+	t := &Trainer{Money: StartingMoney}
+	t.init()
+	t.Bag["poke-ball"] = StartingBalls
+	return t
+}
+
+// init fills in nil maps and the ID counter, e.g. after deserializing.
+func (t *Trainer) init() {
+	if t.Pokedex.Seen == nil {
+		t.Pokedex.Seen = map[string]bool{}
+	}
+	if t.Pokedex.Caught == nil {
+		t.Pokedex.Caught = map[string]bool{}
+	}
+	if t.NextID < 1 {
+		t.NextID = 1
+	}
+	if t.Bag == nil {
+		t.Bag = map[string]int{}
+	}
+}
+
+func (t *Trainer) UnmarshalJSON(data []byte) error {
+	type plain Trainer
+	if err := json.Unmarshal(data, (*plain)(t)); err != nil {
+		return err
+	}
+	t.init()
+	return nil
+}
+
+func (t *Trainer) See(name string) {
+	t.Pokedex.Seen[name] = true
+}
+
+// Add records a newly caught Pokemon, placing it in the party if there is
+// room and otherwise in the first box with space. It returns the new
+// Pokemon and the box it went to, or -1 for the party.
+func (t *Trainer) Add(name, species string) (*Pokemon, int) {
+	p := &Pokemon{
+		ID:       t.NextID,
+		Name:     name,
+		Species:  species,
+		CaughtAt: time.Now(),
+	}
+	t.NextID++
+	t.Pokedex.Seen[name] = true
+	t.Pokedex.Caught[name] = true
+	if len(t.Party) < PartySize {
+		t.Party = append(t.Party, p)
+		return p, -1
+	}
+	return p, t.store(p)
+}
+
+func (t *Trainer) store(p *Pokemon) int {
+	for i, box := range t.Boxes {
+		if len(box) < BoxSize {
+			t.Boxes[i] = append(box, p)
+			return i
+		}
+	}
+	t.Boxes = append(t.Boxes, []*Pokemon{p})
+	return len(t.Boxes) - 1
+}
+
+// Find looks a Pokemon up by ID ("3" or "#3") or by name, searching the
+// party before the boxes. It returns the box index, -1 for the party, and
+// the slot within it.
+func (t *Trainer) Find(ref string) (*Pokemon, int, int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(ref, "#"))
+	match := func(p *Pokemon) bool {
+		if err == nil {
+			return p.ID == id
+		}
+		return p.Name == ref
+	}
+	for slot, p := range t.Party {
+		if match(p) {
+			return p, -1, slot, nil
+		}
+	}
+	for box, pokemon := range t.Boxes {
+		for slot, p := range pokemon {
+			if match(p) {
+				return p, box, slot, nil
+			}
+		}
+	}
+	return nil, 0, 0, fmt.Errorf("%w: %s", ErrNotFound, ref)
+}
+
+// FindInParty is like Find but only searches the party.
+func (t *Trainer) FindInParty(ref string) (*Pokemon, error) {
+	p, box, _, err := t.Find(ref)
+	if err != nil {
+		return nil, err
+	}
+	if box != -1 {
+		return nil, fmt.Errorf("%s is in box %d, not your party", p, box+1)
+	}
+	return p, nil
+}
+
+// All returns every caught Pokemon, party first.
+func (t *Trainer) All() []*Pokemon {
+	all := append([]*Pokemon{}, t.Party...)
+	for _, box := range t.Boxes {
+		all = append(all, box...)
+	}
+	return all
+}
+
+func (t *Trainer) Deposit(ref string) (*Pokemon, int, error) {
+	p, box, slot, err := t.Find(ref)
+	if err != nil {
+		return nil, 0, err
+	}
+	if box != -1 {
+		return nil, 0, fmt.Errorf("%s is already in box %d", p, box+1)
+	}
+	if len(t.Party) == 1 {
+		return nil, 0, ErrLastInParty
+	}
+	t.Party = remove(t.Party, slot)
+	return p, t.store(p), nil
+}
+
+func (t *Trainer) Withdraw(ref string) (*Pokemon, error) {
+	p, box, slot, err := t.Find(ref)
+	if err != nil {
+		return nil, err
+	}
+	if box == -1 {
+		return nil, fmt.Errorf("%s is already in your party", p)
+	}
+	if len(t.Party) >= PartySize {
+		return nil, ErrPartyFull
+	}
+	t.Boxes[box] = remove(t.Boxes[box], slot)
+	t.Party = append(t.Party, p)
+	return p, nil
+}
+
+// Swap exchanges the positions of two Pokemon, whether they are in the
+// party or the boxes.
+func (t *Trainer) Swap(a, b string) error {
+	pa, boxA, slotA, err := t.Find(a)
+	if err != nil {
+		return err
+	}
+	pb, boxB, slotB, err := t.Find(b)
+	if err != nil {
+		return err
+	}
+	*t.slot(boxA, slotA), *t.slot(boxB, slotB) = pb, pa
+	return nil
+}
+
+func (t *Trainer) Release(ref string) (*Pokemon, error) {
+	p, box, slot, err := t.Find(ref)
+	if err != nil {
+		return nil, err
+	}
+	if box == -1 {
+		if len(t.Party) == 1 {
+			return nil, ErrLastInParty
+		}
+		t.Party = remove(t.Party, slot)
+	} else {
+		t.Boxes[box] = remove(t.Boxes[box], slot)
+	}
+	return p, nil
+}
+
+// Replace changes which Pokemon an instance is, e.g. after evolving.
+func (t *Trainer) Replace(p *Pokemon, name, species string) {
+	p.Name = name
+	p.Species = species
+	t.Pokedex.Seen[name] = true
+	t.Pokedex.Caught[name] = true
+}
+
+func (t *Trainer) AddItem(name string, n int) {
+	t.Bag[name] += n
+}
+
+// UseItem removes n of an item from the bag.
+func (t *Trainer) UseItem(name string, n int) error {
+	if t.Bag[name] < n {
+		return fmt.Errorf("%w: %s", ErrNoItem, name)
+	}
+	t.Bag[name] -= n
+	if t.Bag[name] == 0 {
+		delete(t.Bag, name)
+	}
+	return nil
+}
+
+func (t *Trainer) Spend(amount int) error {
+	if amount < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeAmount, amount)
+	}
+	if t.Money < amount {
+		return ErrNotEnoughMoney
+	}
+	t.Money -= amount
+	return nil
+}
+
+func (t *Trainer) Earn(amount int) {
+	t.Money += amount
+}
+
+func (t *Trainer) slot(box, slot int) **Pokemon {
+	if box == -1 {
+		return &t.Party[slot]
+	}
+	return &t.Boxes[box][slot]
+}
+
+func remove(pokemon []*Pokemon, i int) []*Pokemon {
+	return append(pokemon[:i:i], pokemon[i+1:]...)
+}
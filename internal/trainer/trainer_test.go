@@ -0,0 +1,152 @@
+package trainer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func fill(t *Trainer, n int) {
+	for i := 0; i < n; i++ {
+		t.Add(fmt.Sprintf("pokemon-%d", i), fmt.Sprintf("pokemon-%d", i))
+	}
+}
+
+func TestAddUniqueIDs(t *testing.T) {
+	tr := New()
+	a, _ := tr.Add("pikachu", "pikachu")
+	b, _ := tr.Add("pikachu", "pikachu")
+	if a.ID == b.ID {
+		t.Errorf("two catches share ID %d", a.ID)
+		t.Fail()
+	}
+	if len(tr.Party) != 2 {
+		t.Errorf("second pikachu overwrote the first")
+		t.Fail()
+	}
+	if !tr.Pokedex.Caught["pikachu"] || !tr.Pokedex.Seen["pikachu"] {
+		t.Errorf("pikachu was not recorded in the pokedex")
+		t.Fail()
+	}
+}
+
+func TestAddOverflowsToBox(t *testing.T) {
+	tr := New()
+	fill(tr, PartySize)
+	p, box := tr.Add("eevee", "eevee")
+	if box != 0 || len(tr.Party) != PartySize {
+		t.Errorf("seventh Pokemon should go to box 1, went to %d", box)
+		t.Fail()
+	}
+	if found, foundBox, _, err := tr.Find("eevee"); err != nil || found != p || foundBox != 0 {
+		t.Errorf("eevee not found in box 1: %v", err)
+		t.Fail()
+	}
+}
+
+func TestBoxesAreUnlimited(t *testing.T) {
+	tr := New()
+	fill(tr, PartySize+BoxSize+1)
+	if len(tr.Boxes) != 2 || len(tr.Boxes[1]) != 1 {
+		t.Errorf("expected a second box with one Pokemon, got %d boxes", len(tr.Boxes))
+		t.Fail()
+	}
+}
+
+func TestDepositWithdraw(t *testing.T) {
+	tr := New()
+	fill(tr, 2)
+	if _, box, err := tr.Deposit("#1"); err != nil || box != 0 {
+		t.Fatalf("error depositing: %v", err)
+	}
+	if _, _, err := tr.Deposit("#2"); !errors.Is(err, ErrLastInParty) {
+		t.Errorf("expected ErrLastInParty, got %v", err)
+		t.Fail()
+	}
+	if _, err := tr.Withdraw("1"); err != nil {
+		t.Fatalf("error withdrawing: %v", err)
+	}
+	if len(tr.Party) != 2 || len(tr.Boxes[0]) != 0 {
+		t.Errorf("withdraw did not move #1 back to the party")
+		t.Fail()
+	}
+	fill(tr, PartySize)
+	tr.Deposit("#1")
+	fill(tr, 1)
+	if _, err := tr.Withdraw("#1"); !errors.Is(err, ErrPartyFull) {
+		t.Errorf("expected ErrPartyFull, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestSwap(t *testing.T) {
+	tr := New()
+	fill(tr, PartySize+1)
+	if err := tr.Swap("#1", "#7"); err != nil {
+		t.Fatal(err)
+	}
+	if tr.Party[0].ID != 7 || tr.Boxes[0][0].ID != 1 {
+		t.Errorf("swap did not exchange #1 and #7")
+		t.Fail()
+	}
+}
+
+func TestRelease(t *testing.T) {
+	tr := New()
+	fill(tr, 2)
+	if _, err := tr.Release("#2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := tr.Find("#2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("released Pokemon still found")
+		t.Fail()
+	}
+	if _, err := tr.Release("#1"); !errors.Is(err, ErrLastInParty) {
+		t.Errorf("expected ErrLastInParty, got %v", err)
+		t.Fail()
+	}
+}
+
+func TestUnmarshalInitializes(t *testing.T) {
+	var tr Trainer
+	if err := json.Unmarshal([]byte(`{"party": []}`), &tr); err != nil {
+		t.Fatal(err)
+	}
+	tr.Add("pikachu", "pikachu")
+	if tr.Party[0].ID != 1 {
+		t.Errorf("expected first ID 1, got %d", tr.Party[0].ID)
+		t.Fail()
+	}
+}
+
+func TestBagAndMoney(t *testing.T) {
+	tr := New()
+	if tr.Money != StartingMoney || tr.Bag["poke-ball"] != StartingBalls {
+		t.Fatalf("unexpected starting money and bag: %d, %v", tr.Money, tr.Bag)
+	}
+	if err := tr.UseItem("poke-ball", StartingBalls); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tr.Bag["poke-ball"]; ok {
+		t.Errorf("used up items should leave the bag")
+		t.Fail()
+	}
+	if err := tr.UseItem("poke-ball", 1); !errors.Is(err, ErrNoItem) {
+		t.Errorf("expected ErrNoItem, got %v", err)
+		t.Fail()
+	}
+	if err := tr.Spend(StartingMoney + 1); !errors.Is(err, ErrNotEnoughMoney) {
+		t.Errorf("expected ErrNotEnoughMoney, got %v", err)
+		t.Fail()
+	}
+	if err := tr.Spend(-1); !errors.Is(err, ErrNegativeAmount) || tr.Money != StartingMoney {
+		t.Errorf("expected ErrNegativeAmount and no change in money, got %d (%v)", tr.Money, err)
+		t.Fail()
+	}
+	tr.Earn(100)
+	if err := tr.Spend(StartingMoney + 1); err != nil || tr.Money != 99 {
+		t.Errorf("expected 99 left after spending, got %d (%v)", tr.Money, err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,127 @@
+package typechart
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+// Names lists the 18 types in the order used by the chart.
+var Names = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// Chart holds the damage multiplier for every attacking type against every
+// defending type.
+type Chart struct {
+	index  map[string]int
+	matrix [][]float64
+}
+
+type Multiplier struct {
+	Type       string
+	Multiplier float64
+}
+
+// Matchup holds, for each of the attacker's types, its multiplier against
+// the defender, and for each of the defender's types, its multiplier against
+// the attacker.
+type Matchup struct {
+	Offense []Multiplier
+	Defense []Multiplier
+}
+
+func newChart() *Chart {
+	c := &Chart{
+		index:  make(map[string]int, len(Names)),
+		matrix: make([][]float64, len(Names)),
+	}
+	for i, name := range Names {
+		c.index[name] = i
+		c.matrix[i] = make([]float64, len(Names))
+		for j := range c.matrix[i] {
+			c.matrix[i][j] = 1
+		}
+	}
+	return c
+}
+
+// Build constructs a chart from the damage relations of each type.
+func Build(types []pokeapi.Type) *Chart {
+	c := newChart()
+	for _, t := range types {
+		i, ok := c.index[t.Name]
+		if !ok {
+			continue
+		}
+		relations := []struct {
+			targets    []pokeapi.NamedResource
+			multiplier float64
+		}{
+			{t.DamageRelations.DoubleDamageTo, 2},
+			{t.DamageRelations.HalfDamageTo, 0.5},
+			{t.DamageRelations.NoDamageTo, 0},
+		}
+		for _, r := range relations {
+			for _, target := range r.targets {
+				if j, ok := c.index[target.Name]; ok {
+					c.matrix[i][j] = r.multiplier
+				}
+			}
+		}
+	}
+	return c
+}
+
+// Load fetches every type concurrently and builds a chart from them.
+func Load(ctx context.Context, client *pokeapi.Client) (*Chart, error) {
+	types := make([]pokeapi.Type, len(Names))
+	errs := make([]error, len(Names))
+	var wg sync.WaitGroup
+	for i, name := range Names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			types[i], errs[i] = client.GetType(ctx, name)
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("error getting type %s: %w", Names[i], err)
+		}
+	}
+	return Build(types), nil
+}
+
+// Effectiveness returns the multiplier for a move of type attack against a
+// Pokemon with the given types. Unknown types are neutral.
+func (c *Chart) Effectiveness(attack string, defenders ...string) float64 {
+	i, ok := c.index[attack]
+	if !ok {
+		return 1
+	}
+	multiplier := 1.0
+	for _, defender := range defenders {
+		if j, ok := c.index[defender]; ok {
+			multiplier *= c.matrix[i][j]
+		}
+	}
+	return multiplier
+}
+
+// Matchup compares two Pokemon by their types.
+func (c *Chart) Matchup(attacker, defender []string) Matchup {
+	var m Matchup
+	for _, t := range attacker {
+		m.Offense = append(m.Offense, Multiplier{Type: t, Multiplier: c.Effectiveness(t, defender...)})
+	}
+	for _, t := range defender {
+		m.Defense = append(m.Defense, Multiplier{Type: t, Multiplier: c.Effectiveness(t, attacker...)})
+	}
+	return m
+}
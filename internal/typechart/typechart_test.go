@@ -0,0 +1,58 @@
+package typechart
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/faust-m/pokedexcli/internal/fixture"
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+func loadTestChart(t *testing.T) *Chart {
+	t.Helper()
+	client := pokeapi.NewClient(
+		pokeapi.WithHTTPClient(&http.Client{Transport: fixture.FromFlags("../../testdata/fixtures")}),
+		pokeapi.WithRateLimit(0, 0),
+	)
+	chart, err := Load(context.Background(), client)
+	if err != nil {
+		t.Fatalf("error loading chart: %v", err)
+	}
+	return chart
+}
+
+func TestEffectiveness(t *testing.T) {
+	chart := loadTestChart(t)
+	cases := []struct {
+		attack    string
+		defenders []string
+		expected  float64
+	}{
+		{"electric", []string{"water", "flying"}, 4},
+		{"electric", []string{"ground"}, 0},
+		{"fire", []string{"water", "rock"}, 0.25},
+		{"grass", []string{"water", "poison"}, 1},
+		{"normal", []string{"ghost"}, 0},
+		{"unknown", []string{"water"}, 1},
+	}
+	for _, c := range cases {
+		if actual := chart.Effectiveness(c.attack, c.defenders...); actual != c.expected {
+			t.Errorf("%s vs %v: expected %v, got %v", c.attack, c.defenders, c.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestMatchup(t *testing.T) {
+	chart := loadTestChart(t)
+	m := chart.Matchup([]string{"electric"}, []string{"water", "flying"})
+	if len(m.Offense) != 1 || m.Offense[0].Multiplier != 4 {
+		t.Errorf("unexpected offense: %+v", m.Offense)
+		t.Fail()
+	}
+	if len(m.Defense) != 2 || m.Defense[0].Multiplier != 1 || m.Defense[1].Multiplier != 0.5 {
+		t.Errorf("unexpected defense: %+v", m.Defense)
+		t.Fail()
+	}
+}
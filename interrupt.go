@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// interrupter turns SIGINT into cancellation of the running command, so
+// Ctrl-C returns to the prompt rather than exiting the Pokedex.
+type interrupter struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func newInterrupter() *interrupter {
+	in := &interrupter{}
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	go in.loop(sigs)
+	return in
+}
+
+func (in *interrupter) loop(sigs <-chan os.Signal) {
+	for range sigs {
+		in.mu.Lock()
+		if in.cancel != nil {
+			in.cancel()
+			in.cancel = nil
+		} else {
+			fmt.Print("\n(use exit to quit)\n" + prompt)
+		}
+		in.mu.Unlock()
+	}
+}
+
+// start returns a context for one command. The returned func must be called
+// once the command finishes.
+func (in *interrupter) start() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in.mu.Lock()
+	in.cancel = cancel
+	in.mu.Unlock()
+	return ctx, func() {
+		in.mu.Lock()
+		in.cancel = nil
+		in.mu.Unlock()
+		cancel()
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestInterrupterCancelsRunningCommand(t *testing.T) {
+	in := &interrupter{}
+	sigs := make(chan os.Signal)
+	go in.loop(sigs)
+	defer close(sigs)
+
+	ctx, done := in.start()
+	defer done()
+	sigs <- os.Interrupt
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("command was not canceled")
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", ctx.Err())
+		t.Fail()
+	}
+
+	next, nextDone := in.start()
+	defer nextDone()
+	if next.Err() != nil {
+		t.Errorf("next command should not start canceled")
+		t.Fail()
+	}
+}
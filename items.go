@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+	"github.com/faust-m/pokedexcli/internal/trainer"
+)
+
+// shopStock is what the shop sells. Prices come from the item costs in
+// PokeAPI, and anything with a cost can be sold back for half.
+var shopStock = []string{"poke-ball", "great-ball", "ultra-ball"}
+
+func formatMoney(amount int) string {
+	return fmt.Sprintf("₽%d", amount)
+}
+
+func commandBag(_ context.Context, cfg *config, _ ...string) error {
+	fmt.Printf("Money: %s\n", formatMoney(cfg.trainer.Money))
+	if len(cfg.trainer.Bag) == 0 {
+		fmt.Println("your bag is empty")
+		return nil
+	}
+	fmt.Println("Bag:")
+	for _, name := range slices.Sorted(maps.Keys(cfg.trainer.Bag)) {
+		fmt.Printf(" - %s x%d\n", name, cfg.trainer.Bag[name])
+	}
+	return nil
+}
+
+func commandShop(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		fmt.Println("For sale:")
+		for _, name := range shopStock {
+			item, err := getItem(ctx, cfg, name)
+			if err != nil {
+				return err
+			}
+			fmt.Printf(" - %s: %s\n", name, formatMoney(item.Cost))
+		}
+		fmt.Printf("You have %s.\n", formatMoney(cfg.trainer.Money))
+		return nil
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("usage: shop buy|sell <item> [count]")
+	}
+	count := 1
+	if len(args) > 2 {
+		n, err := strconv.Atoi(args[2])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid count: %s", args[2])
+		}
+		count = n
+	}
+	switch args[0] {
+	case "buy":
+		return buyItem(ctx, cfg, args[1], count)
+	case "sell":
+		return sellItem(ctx, cfg, args[1], count)
+	}
+	return fmt.Errorf("usage: shop buy|sell <item> [count]")
+}
+
+func buyItem(ctx context.Context, cfg *config, name string, count int) error {
+	if !slices.Contains(shopStock, name) {
+		return fmt.Errorf("the shop does not sell %s", name)
+	}
+	item, err := getItem(ctx, cfg, name)
+	if err != nil {
+		return err
+	}
+	// Checking before multiplying keeps a huge count from overflowing.
+	if item.Cost > 0 && count > cfg.trainer.Money/item.Cost {
+		return fmt.Errorf("%w: %d %s cost more than %s", trainer.ErrNotEnoughMoney, count, name, formatMoney(cfg.trainer.Money))
+	}
+	if err := cfg.trainer.Spend(item.Cost * count); err != nil {
+		return fmt.Errorf("%w: %d %s cost %s", err, count, name, formatMoney(item.Cost*count))
+	}
+	cfg.trainer.AddItem(name, count)
+	fmt.Printf("Bought %d %s for %s. You have %s left.\n", count, name, formatMoney(item.Cost*count), formatMoney(cfg.trainer.Money))
+	return autosave(cfg)
+}
+
+func sellItem(ctx context.Context, cfg *config, name string, count int) error {
+	if cfg.trainer.Bag[name] < count {
+		return fmt.Errorf("you only have %d %s", cfg.trainer.Bag[name], name)
+	}
+	item, err := getItem(ctx, cfg, name)
+	if err != nil {
+		return err
+	}
+	if item.Cost == 0 {
+		return fmt.Errorf("the shop will not buy %s", name)
+	}
+	if err := cfg.trainer.UseItem(name, count); err != nil {
+		return err
+	}
+	price := item.Cost / 2 * count
+	cfg.trainer.Earn(price)
+	fmt.Printf("Sold %d %s for %s. You have %s.\n", count, name, formatMoney(price), formatMoney(cfg.trainer.Money))
+	return autosave(cfg)
+}
+
+func getItem(ctx context.Context, cfg *config, name string) (pokeapi.Item, error) {
+	item, err := cfg.client.GetItem(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return pokeapi.Item{}, fmt.Errorf("no such item: %s", name)
+	} else if err != nil {
+		return pokeapi.Item{}, fmt.Errorf("error getting item: %w", err)
+	}
+	return item, nil
+}
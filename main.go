@@ -1,29 +1,62 @@
 package main
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
-	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/faust-m/pokedexcli/internal/catch"
+	"github.com/faust-m/pokedexcli/internal/evolution"
+	"github.com/faust-m/pokedexcli/internal/output"
 	"github.com/faust-m/pokedexcli/internal/pokeapi"
+	"github.com/faust-m/pokedexcli/internal/pokecache"
+	"github.com/faust-m/pokedexcli/internal/save"
+	"github.com/faust-m/pokedexcli/internal/stats"
+	"github.com/faust-m/pokedexcli/internal/trainer"
+	"github.com/faust-m/pokedexcli/internal/typechart"
+)
+
+const (
+	cacheInterval   = 24 * time.Hour
+	cacheMaxBytes   = 64 << 20
+	cacheMaxEntries = 1000
+	requestTimeout  = 15 * time.Second
+
+	// language is the language names and descriptions are shown in.
+	language = "en"
 )
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(*config, ...string) error
+	callback    func(context.Context, *config, ...string) error
+	// result is set instead of callback by commands with a structured
+	// result, which can be written in any output format.
+	result func(context.Context, *config, ...string) (output.Result, error)
 }
 
 type config struct {
-	next     *url.URL
-	previous *url.URL
+	mapPage   int
+	savePath  string
+	client    *pokeapi.Client
+	catcher   *catch.Engine
+	trainer   *trainer.Trainer
+	types     *typechart.Chart
+	rng       *rand.Rand
+	encounter *encounter
+	settings  save.Settings
+	// format overrides the saved output format for one command.
+	format output.Format
 }
 
 var cmds map[string]cliCommand
-var pokedex map[string]pokeapi.Pokemon
 
 func init() {
 	cmds = map[string]cliCommand{
@@ -39,68 +72,245 @@ func init() {
 		},
 		"map": {
 			name:        "map",
-			description: "Displays the names of the next 20 location areas",
+			description: "Displays the areas in the next 20 locations of the current region",
 			callback:    commandMap,
 		},
 		"mapb": {
 			name:        "mapb",
-			description: "Displays the names of the previous 20 location areas",
+			description: "Displays the areas in the previous 20 locations of the current region",
 			callback:    commandMapb,
 		},
+		"travel": {
+			name:        "travel",
+			description: "Travel to a location in the current region, or to another region",
+			callback:    commandTravel,
+		},
+		"where": {
+			name:        "where",
+			description: "Show where you are and the areas you can explore",
+			callback:    commandWhere,
+		},
 		"explore": {
 			name:        "explore",
-			description: "List Pokemon found in a given area",
-			callback:    commandExplore,
+			description: "Search an area of the current location for a wild Pokemon, optionally --method surf, old-rod, ..., or --list its Pokemon",
+			result:      commandExplore,
 		},
 		"catch": {
 			name:        "catch",
-			description: "Attempt to catch a Pokemon",
+			description: "Attempt to catch the wild Pokemon you encountered, optionally --ball great-ball",
 			callback:    commandCatch,
 		},
+		"fight": {
+			name:        "fight",
+			description: "Send out a caught Pokemon to battle the wild Pokemon",
+			callback:    commandFight,
+		},
+		"attack": {
+			name:        "attack",
+			description: "Use a move in the current battle",
+			callback:    commandAttack,
+		},
+		"run": {
+			name:        "run",
+			description: "Run from the wild Pokemon",
+			callback:    commandRun,
+		},
+		"party": {
+			name:        "party",
+			description: "List the Pokemon in your party",
+			callback:    commandParty,
+		},
+		"box": {
+			name:        "box",
+			description: "List the Pokemon in your PC boxes, or in one box",
+			callback:    commandBox,
+		},
+		"deposit": {
+			name:        "deposit",
+			description: "Move a party Pokemon into a PC box",
+			callback:    commandDeposit,
+		},
+		"withdraw": {
+			name:        "withdraw",
+			description: "Move a Pokemon from a PC box into your party",
+			callback:    commandWithdraw,
+		},
+		"swap": {
+			name:        "swap",
+			description: "Swap the places of two caught Pokemon",
+			callback:    commandSwap,
+		},
+		"release": {
+			name:        "release",
+			description: "Release a caught Pokemon",
+			callback:    commandRelease,
+		},
+		"moves": {
+			name:        "moves",
+			description: "List the moves a Pokemon can learn in the chosen game",
+			callback:    commandMoves,
+		},
+		"move": {
+			name:        "move",
+			description: "Show details of a move",
+			callback:    commandMove,
+		},
+		"learn": {
+			name:        "learn",
+			description: "Teach a caught Pokemon a move, optionally replacing one: learn <pokemon> <move> [old move]",
+			callback:    commandLearn,
+		},
+		"forget": {
+			name:        "forget",
+			description: "Make a caught Pokemon forget a move",
+			callback:    commandForget,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Show what an ability does and which Pokemon have it",
+			callback:    commandAbility,
+		},
+		"nature": {
+			name:        "nature",
+			description: "Show the stats a nature raises and lowers",
+			callback:    commandNature,
+		},
+		"sprite": {
+			name:        "sprite",
+			description: "Draw a Pokemon's sprite, optionally shiny or from the back",
+			callback:    commandSprite,
+		},
+		"format": {
+			name:        "format",
+			description: "Show or set the output format: text, json, csv or table",
+			callback:    commandFormat,
+		},
+		"bag": {
+			name:        "bag",
+			description: "List your items and money",
+			callback:    commandBag,
+		},
+		"shop": {
+			name:        "shop",
+			description: "List the shop's items, or buy or sell with 'buy|sell <item> [count]'",
+			callback:    commandShop,
+		},
 		"inspect": {
 			name:        "inspect",
-			description: "List attributes for a caught Pokemon",
-			callback:    commandInspect,
+			description: "List attributes for a caught Pokemon, with --sprite to draw it",
+			result:      commandInspect,
 		},
 		"pokedex": {
 			name:        "pokedex",
-			description: "List Pokemon names in your Pokedex",
-			callback:    commandPokedex,
+			description: "List the Pokemon you have seen and caught",
+			result:      commandPokedex,
+		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "Show the evolution chain for a Pokemon",
+			callback:    commandEvolutions,
+		},
+		"evolve": {
+			name:        "evolve",
+			description: "Evolve a caught Pokemon, optionally using an item or 'trade'",
+			callback:    commandEvolve,
+		},
+		"matchup": {
+			name:        "matchup",
+			description: "Compare type effectiveness between two Pokemon",
+			callback:    commandMatchup,
+		},
+		"version": {
+			name:        "version",
+			description: "Show or choose the game version used for encounters, moves, items and sprites",
+			callback:    commandVersion,
+		},
+		"versions": {
+			name:        "versions",
+			description: "List the game versions you can choose from",
+			callback:    commandVersions,
+		},
+		"cache": {
+			name:        "cache",
+			description: "Show cache usage with 'stats' or empty it with 'clear'",
+			callback:    commandCache,
+		},
+		"save": {
+			name:        "save",
+			description: "Save your Pokedex, optionally to a given file",
+			callback:    commandSave,
+		},
+		"load": {
+			name:        "load",
+			description: "Load your Pokedex, optionally from a given file, which is then autosaved to",
+			callback:    commandLoad,
 		},
 	}
-
-	pokedex = map[string]pokeapi.Pokemon{}
 }
 
 func main() {
-	scanner := bufio.NewScanner(os.Stdin)
 	cfg := config{
-		next:     &url.URL{},
-		previous: &url.URL{},
-	}
-	for {
-		fmt.Print("Pokedex > ")
-		if scanner.Scan() {
-			if text := scanner.Text(); len(text) > 0 {
-				fmtInput := cleanInput(text)
-				value, ok := cmds[fmtInput[0]]
-				if !ok {
-					fmt.Println("Unknown command")
-					continue
-				}
-				if err := value.callback(&cfg, fmtInput[1:]...); err != nil {
-					fmt.Println("Error:", err)
-				}
-			}
-		}
+		client: pokeapi.NewClient(
+			pokeapi.WithCache(newCache()),
+			pokeapi.WithTimeout(requestTimeout),
+		),
+		catcher: catch.NewEngine(time.Now().UnixNano()),
+		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		trainer: trainer.New(),
+	}
+	savePath, err := save.DefaultPath()
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+	if err := openSave(&cfg, savePath); err != nil {
+		fmt.Println("Error:", err)
 	}
+	os.Exit(run(&cfg, os.Args[1:]))
+}
+
+func newCache() *pokecache.Cache {
+	opts := []pokecache.Option{
+		pokecache.WithMaxBytes(cacheMaxBytes),
+		pokecache.WithMaxEntries(cacheMaxEntries),
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		fmt.Println("Error: disk cache disabled:", err)
+	} else {
+		opts = append(opts, pokecache.WithDiskDir(filepath.Join(cacheDir, "pokedexcli")))
+	}
+	return pokecache.NewCache(cacheInterval, opts...)
 }
 
 func cleanInput(text string) []string {
 	return strings.Fields(strings.ToLower(text))
 }
 
-func commandExit(*config, ...string) error {
+// flagValue removes a "--name value" pair from args, returning the value or
+// "" if the flag is absent.
+func flagValue(args []string, name string) (string, []string, error) {
+	i := slices.Index(args, name)
+	if i == -1 {
+		return "", args, nil
+	}
+	if i == len(args)-1 {
+		return "", nil, fmt.Errorf("%s needs a value", name)
+	}
+	value := args[i+1]
+	return value, append(args[:i:i], args[i+2:]...), nil
+}
+
+// hasFlag removes a "--name" switch from args, reporting whether it was
+// present.
+func hasFlag(args []string, name string) (bool, []string) {
+	i := slices.Index(args, name)
+	if i == -1 {
+		return false, args
+	}
+	return true, append(args[:i:i], args[i+1:]...)
+}
+
+func commandExit(context.Context, *config, ...string) error {
 	if _, err := fmt.Println("Closing the Pokedex... Goodbye!"); err != nil {
 		return fmt.Errorf("error in commandExit: %w", err)
 	}
@@ -108,7 +318,7 @@ func commandExit(*config, ...string) error {
 	return nil
 }
 
-func commandHelp(*config, ...string) error {
+func commandHelp(context.Context, *config, ...string) error {
 	heading :=
 		`Welcome to the Pokedex!
 Usage:
@@ -125,156 +335,410 @@ Usage:
 	return nil
 }
 
-func commandMap(cfg *config, args ...string) error {
-	var err error
-	if cfg.next == nil {
-		fmt.Println("You're on the last page!")
-		return nil
-	} else if cfg.next.String() == "" {
-		cfg.next, err = url.Parse(pokeapi.BaseURL + pokeapi.LocationAreaEP)
+// commandExplore searches an area of the current location for a wild
+// Pokemon, optionally by an encounter method given with --method. The area
+// may be left out when the location only has one. With --list, the area's
+// encounter table is listed instead.
+func commandExplore(ctx context.Context, cfg *config, args ...string) (output.Result, error) {
+	method, args, err := flagValue(args, "--method")
+	if err != nil {
+		return nil, err
+	}
+	list, args := hasFlag(args, "--list")
+	if len(args) == 0 {
+		location, err := currentLocation(ctx, cfg)
 		if err != nil {
-			return fmt.Errorf("error parsing URL in commandMap: %w", err)
+			return nil, err
+		}
+		if len(location.Areas) != 1 {
+			return nil, fmt.Errorf("no area specified to explore, use where to list them")
 		}
-		q := url.Values{}
-		q.Add(pokeapi.OffsetKey, "0")
-		q.Add(pokeapi.LimitKey, "20")
-		cfg.next.RawQuery = q.Encode()
+		args = []string{location.Areas[0].Name}
+	}
+	exploreData, err := cfg.client.ExploreArea(ctx, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return nil, fmt.Errorf("no such area: %s", args[0])
+	} else if err != nil {
+		return nil, err
+	}
+	if here := position(cfg).Location; exploreData.Location.Name != here {
+		return nil, fmt.Errorf("%s is not in %s, travel to %s first", args[0], here, exploreData.Location.Name)
+	}
+	if list {
+		return newAreaResult(cfg, exploreData), nil
 	}
+	return rollEncounter(ctx, cfg, exploreData, method)
+}
 
-	locationAreas, err := pokeapi.GetLocationAreas(cfg.next.String())
+// commandCatch throws a Poke Ball from the bag at the wild Pokemon currently
+// encountered, a plain poke-ball unless another is chosen with --ball.
+func commandCatch(ctx context.Context, cfg *config, args ...string) error {
+	ballName, args, err := flagValue(args, "--ball")
 	if err != nil {
-		return fmt.Errorf("error getting next location areas: %w", err)
+		return err
+	}
+	if ballName == "" {
+		ballName = "poke-ball"
+	}
+	ball, ok := catch.Balls[ballName]
+	if !ok {
+		return fmt.Errorf("%s is not a Poke Ball", ballName)
+	}
+	if cfg.trainer.Bag[ballName] == 0 {
+		return fmt.Errorf("you have no %s left, buy more at the shop", ballName)
 	}
-	err = updateConfig(cfg, locationAreas)
+	if cfg.encounter == nil {
+		return fmt.Errorf("there is no wild Pokemon to catch, explore to find one")
+	}
+	if len(args) > 0 && args[0] != cfg.encounter.pokemon.Name {
+		return fmt.Errorf("there is no wild %s here, only %s", args[0], cfg.encounter.pokemon.Name)
+	}
+	pokemonData, wild := cfg.encounter.pokemon, cfg.encounter.wild
+	species, err := cfg.client.GetPokemonSpecies(ctx, pokemonData.Species.Name)
 	if err != nil {
-		return fmt.Errorf("error updating config: %w", err)
+		return fmt.Errorf("error getting species data: %w", err)
+	}
+	if err := cfg.trainer.UseItem(ballName, 1); err != nil {
+		return err
+	}
+	fmt.Printf("Throwing a %s at %s...\n", ballName, pokemonData.Name)
+	result := cfg.catcher.Throw(catch.Attempt{
+		CaptureRate: species.CaptureRate,
+		MaxHP:       wild.Stats.HP,
+		CurrentHP:   wild.HP,
+		Ball:        ball,
+		Status:      catch.StatusNone,
+	})
+	for i := 0; i < result.Shakes; i++ {
+		fmt.Println("...shake...")
+	}
+	if result.Caught {
+		rate, err := cfg.client.GetGrowthRate(ctx, species.GrowthRate.Name)
+		if err != nil {
+			return fmt.Errorf("error getting growth rate: %w", err)
+		}
+		caught, box := cfg.trainer.Add(pokemonData.Name, species.Name)
+		caught.Level = wild.Level
+		caught.Experience = stats.Experience(rate, wild.Level)
+		caught.Friendship = species.BaseHappiness
+		caught.Nature = cfg.encounter.nature.Name
+		caught.Ability = cfg.encounter.ability
+		caught.IVs = cfg.encounter.ivs
+		for _, m := range wild.Moves {
+			caught.Moves = append(caught.Moves, m.Name)
+		}
+		cfg.encounter = nil
+		fmt.Printf("%s was caught!\n", caught)
+		if box == -1 {
+			fmt.Println("It was added to your party.")
+		} else {
+			fmt.Printf("Your party is full, so it was sent to box %d.\n", box+1)
+		}
+		fmt.Println("You may now inspect it with the inspect command.")
+	} else {
+		fmt.Printf("%s escaped!\n", pokemonData.Name)
 	}
+	return autosave(cfg)
+}
 
-	for _, result := range locationAreas.Results {
-		fmt.Println(result.Name)
+func commandInspect(ctx context.Context, cfg *config, args ...string) (output.Result, error) {
+	showSprite, args := hasFlag(args, "--sprite")
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no Pokemon specified to inspect")
+	}
+	caught, _, _, err := cfg.trainer.Find(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("you have not caught %s", args[0])
+	}
+	data, err := cfg.client.GetPokemonData(ctx, caught.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Pokemon data: %w", err)
 	}
+	result := inspectResult{
+		ID:         caught.ID,
+		Name:       data.Name,
+		Height:     data.Height,
+		Weight:     data.Weight,
+		Level:      caught.Level,
+		Experience: caught.Experience,
+		Friendship: caught.Friendship,
+		Nature:     caught.Nature,
+		Ability:    caught.Ability,
+		Types:      data.TypeNames(),
+		Abilities:  []abilityEntry{},
+		Moves:      append([]string{}, caught.Moves...),
+		HeldItems:  []heldItemEntry{},
+	}
+	computed := caught.Stats(stats.Base(data))
+	for _, stat := range data.Stats {
+		result.Stats = append(result.Stats, statEntry{Name: stat.Stat.Name, Value: computed.Get(stat.Stat.Name), Base: stat.BaseStat})
+	}
+	for _, a := range data.Abilities {
+		result.Abilities = append(result.Abilities, abilityEntry{Name: a.Ability.Name, Hidden: a.IsHidden})
+	}
+	for _, item := range data.HeldItemsIn(cfg.settings.GameVersion) {
+		result.HeldItems = append(result.HeldItems, heldItemEntry{Name: item.Name, Rarity: item.Rarity})
+	}
+	sprites, _ := data.VersionSprites(cfg.settings.Generation, cfg.settings.VersionGroup)
+	result.Sprite = sprites.FrontDefault
+	if showSprite && result.Sprite != "" && outputFormat(cfg) == output.Text {
+		result.spriteImage, err = cfg.client.GetSprite(ctx, result.Sprite)
+		if err != nil {
+			return nil, fmt.Errorf("error getting sprite: %w", err)
+		}
+	}
+	return result, nil
+}
 
+func commandPokedex(_ context.Context, cfg *config, _ ...string) (output.Result, error) {
+	dex := cfg.trainer.Pokedex
+	result := pokedexResult{Seen: len(dex.Seen), Caught: len(dex.Caught), Pokemon: []pokedexEntry{}}
+	for _, name := range slices.Sorted(maps.Keys(dex.Seen)) {
+		result.Pokemon = append(result.Pokemon, pokedexEntry{Name: name, Caught: dex.Caught[name]})
+	}
+	return result, nil
+}
+
+func commandSave(_ context.Context, cfg *config, args ...string) error {
+	path := cfg.savePath
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if path == "" {
+		return fmt.Errorf("no save file specified")
+	}
+	if err := saveTrainer(cfg, path); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %d Pokemon to %s\n", len(cfg.trainer.All()), path)
 	return nil
 }
 
-func commandMapb(cfg *config, args ...string) error {
-	var err error
-	if cfg.previous == nil {
-		fmt.Println("You're on the first page!")
-		return nil
-	} else if cfg.previous.String() == "" {
-		cfg.previous, err = url.Parse(pokeapi.BaseURL + pokeapi.LocationAreaEP)
-		if err != nil {
-			return fmt.Errorf("error parsing URL in commandMapb: %w", err)
-		}
-		q := url.Values{}
-		q.Add(pokeapi.OffsetKey, "0")
-		q.Add(pokeapi.LimitKey, "20")
-		cfg.next.RawQuery = q.Encode()
+// commandLoad replaces the game with a save file, the default one unless
+// another is given, and autosaves to that file from then on.
+func commandLoad(_ context.Context, cfg *config, args ...string) error {
+	path := cfg.savePath
+	if len(args) > 0 {
+		path = args[0]
 	}
+	if path == "" {
+		return fmt.Errorf("no save file specified")
+	}
+	if err := loadTrainer(cfg, path); err != nil {
+		return err
+	}
+	fmt.Printf("Loaded %d Pokemon from %s\n", len(cfg.trainer.All()), path)
+	// Autosaving to the previous file would overwrite that game with this one.
+	if path != cfg.savePath {
+		cfg.savePath = path
+		fmt.Printf("Autosaving to %s from now on\n", path)
+	}
+	return nil
+}
 
-	locationAreas, err := pokeapi.GetLocationAreas(cfg.previous.String())
-	if err != nil {
-		return fmt.Errorf("error getting next location areas: %w", err)
+func saveTrainer(cfg *config, path string) error {
+	f := save.New()
+	f.Trainer = cfg.trainer
+	f.Settings = cfg.settings
+	if err := save.Write(path, f); err != nil {
+		return fmt.Errorf("error saving pokedex: %w", err)
 	}
-	err = updateConfig(cfg, locationAreas)
+	return nil
+}
+
+func loadTrainer(cfg *config, path string) error {
+	f, err := save.Load(path)
 	if err != nil {
-		return fmt.Errorf("error updating config: %w", err)
+		return fmt.Errorf("error loading pokedex: %w", err)
 	}
+	cfg.trainer = f.Trainer
+	cfg.settings = f.Settings
+	// A wild encounter belongs to the game being replaced.
+	cfg.encounter = nil
+	return nil
+}
 
-	for _, result := range locationAreas.Results {
-		fmt.Println(result.Name)
+// openSave loads the save file at path and autosaves to it from then on.
+// A missing file starts a new game. If the file cannot be loaded, autosave
+// is turned off so a new game does not overwrite it.
+func openSave(cfg *config, path string) error {
+	if path == "" {
+		return nil
+	}
+	err := loadTrainer(cfg, path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w; autosave is off so it is not overwritten", err)
 	}
+	cfg.savePath = path
+	return nil
+}
 
+// autosave writes the save file after progress is made, if one is in use.
+func autosave(cfg *config) error {
+	if cfg.savePath == "" {
+		return nil
+	}
+	if err := saveTrainer(cfg, cfg.savePath); err != nil {
+		return fmt.Errorf("error autosaving: %w", err)
+	}
 	return nil
 }
 
-func updateConfig(cfg *config, locationAreas pokeapi.LocationArea) error {
-	var err error
-	if locationAreas.Previous != nil {
-		cfg.previous, err = url.Parse(*locationAreas.Previous)
-		if err != nil {
-			return fmt.Errorf("error parsing previous URL: %w", err)
-		}
-	} else {
-		cfg.previous = nil
+func commandCache(_ context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: cache stats|clear")
 	}
-	if locationAreas.Next != nil {
-		cfg.next, err = url.Parse(*locationAreas.Next)
+	cache := cfg.client.Cache()
+	switch args[0] {
+	case "stats":
+		entries, size, err := cache.DiskUsage()
 		if err != nil {
-			return fmt.Errorf("error parsing next URL: %w", err)
+			return fmt.Errorf("error reading disk cache: %w", err)
 		}
-	} else {
-		cfg.next = nil
+		stats := cache.Stats()
+		fmt.Printf("Memory entries: %d (%d bytes)\n", stats.Entries, stats.Bytes)
+		fmt.Printf("Hits: %d, misses: %d, evictions: %d, expired: %d\n", stats.Hits, stats.Misses, stats.Evictions, stats.Expired)
+		fmt.Printf("Disk entries: %d (%d bytes)\n", entries, size)
+		if stats.DiskErrors > 0 {
+			fmt.Printf("Disk write errors: %d, last: %s\n", stats.DiskErrors, stats.LastDiskError)
+		}
+	case "clear":
+		if err := cache.Clear(); err != nil {
+			return fmt.Errorf("error clearing cache: %w", err)
+		}
+		fmt.Println("Cache cleared")
+	default:
+		return fmt.Errorf("unknown cache command: %s", args[0])
 	}
 	return nil
 }
 
-func commandExplore(cfg *config, args ...string) error {
+func commandEvolutions(ctx context.Context, cfg *config, args ...string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("no area specified to explore")
+		return fmt.Errorf("no Pokemon specified")
 	}
-	fmt.Printf("Exploring %s...\n", args[0])
-	exploreData, err := pokeapi.ExploreArea(fmt.Sprintf("%s%s/%s", pokeapi.BaseURL, pokeapi.LocationAreaEP, args[0]))
+	_, chain, err := getEvolutionChain(ctx, cfg, args[0])
 	if err != nil {
-		return fmt.Errorf("error exploring %s", args[0])
+		return err
 	}
-	if len(exploreData.PokemonEncounters) > 0 {
-		fmt.Println("Found Pokemon:")
-		for _, pokemon := range exploreData.PokemonEncounters {
-			fmt.Printf(" - %s\n", pokemon.Pokemon.Name)
-		}
-	}
-	return nil
+	return evolution.Render(os.Stdout, chain.Chain)
 }
 
-func commandCatch(cfg *config, args ...string) error {
+func commandEvolve(ctx context.Context, cfg *config, args ...string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("no Pokemon specified to catch")
+		return fmt.Errorf("no Pokemon specified to evolve")
 	}
-	fmt.Printf("Throwing a Pokeball at %s...\n", args[0])
-	pokemonData, err := pokeapi.GetPokemonData(fmt.Sprintf("%s%s/%s", pokeapi.BaseURL, pokeapi.PokemonEP, args[0]))
+	caught, _, _, err := cfg.trainer.Find(args[0])
+	if err != nil {
+		return fmt.Errorf("you have not caught %s", args[0])
+	}
+	species, chain, err := getEvolutionChain(ctx, cfg, caught.Species)
+	if err != nil {
+		return err
+	}
+	link, ok := evolution.Find(chain.Chain, species.Name)
+	if !ok {
+		return fmt.Errorf("%s is missing from its evolution chain", species.Name)
+	}
+
+	conditions := evolution.Conditions{
+		Level:     caught.Level,
+		Happiness: caught.Friendship,
+		TimeOfDay: evolution.TimeOfDay(time.Now().Hour()),
+		Location:  position(cfg).Location,
+	}
+	if len(args) > 1 {
+		if args[1] == "trade" {
+			conditions.Trade = true
+		} else {
+			conditions.Item = args[1]
+		}
+	}
+	next, err := evolution.Next(link, conditions)
+	if err != nil {
+		return fmt.Errorf("%s cannot evolve yet: %w", caught.Name, err)
+	}
+	nextSpecies, err := cfg.client.GetPokemonSpecies(ctx, next)
+	if err != nil {
+		return fmt.Errorf("error getting species data: %w", err)
+	}
+	evolved, err := cfg.client.GetPokemonData(ctx, nextSpecies.DefaultPokemon())
 	if err != nil {
 		return fmt.Errorf("error getting Pokemon data: %w", err)
 	}
-	if rand.Intn(pokemonData.BaseExperience) <= 40 {
-		pokedex[pokemonData.Name] = pokemonData
-		fmt.Printf("%s was caught!\n", pokemonData.Name)
-		fmt.Println("You may now inspect it with the inspect command.")
-	} else {
-		fmt.Printf("%s escaped!\n", pokemonData.Name)
+	previous := caught.Name
+	cfg.trainer.Replace(caught, evolved.Name, next)
+	fmt.Printf("%s evolved into %s!\n", previous, evolved.Name)
+	return autosave(cfg)
+}
+
+func getEvolutionChain(ctx context.Context, cfg *config, name string) (pokeapi.PokemonSpecies, pokeapi.EvolutionChain, error) {
+	species, err := cfg.client.GetPokemonSpecies(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return pokeapi.PokemonSpecies{}, pokeapi.EvolutionChain{}, fmt.Errorf("no such Pokemon: %s", name)
+	} else if err != nil {
+		return pokeapi.PokemonSpecies{}, pokeapi.EvolutionChain{}, fmt.Errorf("error getting species data: %w", err)
 	}
-	return nil
+	chain, err := cfg.client.GetEvolutionChain(ctx, species.EvolutionChain.URL)
+	if err != nil {
+		return pokeapi.PokemonSpecies{}, pokeapi.EvolutionChain{}, fmt.Errorf("error getting evolution chain: %w", err)
+	}
+	return species, chain, nil
 }
 
-func commandInspect(cfg *config, args ...string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("no Pokemon specified to inspect")
+func commandMatchup(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: matchup <attacker> <defender>")
 	}
-	data, ok := pokedex[args[0]]
-	if !ok {
-		fmt.Println("you have not caught that pokemon")
-		return nil
+	attacker, err := lookupPokemon(ctx, cfg, args[0])
+	if err != nil {
+		return err
 	}
-	fmt.Printf("Name: %s\nHeight: %v\nWeight: %v\nStats:\n", data.Name, data.Height, data.Weight)
-	for _, stat := range data.Stats {
-		fmt.Printf(" -%s: %v\n", stat.Stat.Name, stat.BaseStat)
+	defender, err := lookupPokemon(ctx, cfg, args[1])
+	if err != nil {
+		return err
 	}
-	fmt.Println("Types:")
-	for _, t := range data.Types {
-		fmt.Printf(" - %s\n", t.Type.Name)
+	chart, err := typeChart(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	attackerTypes, defenderTypes := attacker.TypeNames(), defender.TypeNames()
+	matchup := chart.Matchup(attackerTypes, defenderTypes)
+	fmt.Printf("%s (%s) vs %s (%s)\n", attacker.Name, strings.Join(attackerTypes, "/"), defender.Name, strings.Join(defenderTypes, "/"))
+	fmt.Println("Offense:")
+	for _, m := range matchup.Offense {
+		fmt.Printf(" - %s: %vx\n", m.Type, m.Multiplier)
+	}
+	fmt.Println("Defense:")
+	for _, m := range matchup.Defense {
+		fmt.Printf(" - %s: %vx\n", m.Type, m.Multiplier)
 	}
 	return nil
 }
 
-func commandPokedex(*config, ...string) error {
-	if len(pokedex) == 0 {
-		fmt.Println("you have no pokemon in your pokedex")
-	} else {
-		fmt.Println("Pokedex:")
-		for k := range pokedex {
-			fmt.Printf(" - %s\n", pokedex[k].Name)
+// lookupPokemon resolves a caught Pokemon by ID or name, and otherwise
+// treats name as a Pokemon to look up on PokeAPI.
+func lookupPokemon(ctx context.Context, cfg *config, name string) (pokeapi.Pokemon, error) {
+	if caught, _, _, err := cfg.trainer.Find(name); err == nil {
+		name = caught.Name
+	}
+	data, err := cfg.client.GetPokemonData(ctx, name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return pokeapi.Pokemon{}, fmt.Errorf("no such Pokemon: %s", name)
+	} else if err != nil {
+		return pokeapi.Pokemon{}, fmt.Errorf("error getting Pokemon data: %w", err)
+	}
+	return data, nil
+}
+
+// typeChart loads the type chart on first use and keeps it for the session.
+func typeChart(ctx context.Context, cfg *config) (*typechart.Chart, error) {
+	if cfg.types == nil {
+		chart, err := typechart.Load(ctx, cfg.client)
+		if err != nil {
+			return nil, fmt.Errorf("error loading type chart: %w", err)
 		}
+		cfg.types = chart
 	}
-	return nil
+	return cfg.types, nil
 }
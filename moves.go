@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/faust-m/pokedexcli/internal/learnset"
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+	"github.com/faust-m/pokedexcli/internal/trainer"
+)
+
+// versionGroup is the version group whose learnsets are used: the chosen
+// game's, or otherwise the newest one the Pokemon appears in.
+func versionGroup(cfg *config, data pokeapi.Pokemon) string {
+	if cfg.settings.VersionGroup != "" {
+		return cfg.settings.VersionGroup
+	}
+	return learnset.Latest(data)
+}
+
+// teachMove teaches a move learned by levelling up if there is a free slot.
+func teachMove(p *trainer.Pokemon, move string) {
+	switch {
+	case slices.Contains(p.Moves, move):
+	case len(p.Moves) < learnset.MaxMoves:
+		p.Moves = append(p.Moves, move)
+		fmt.Printf("%s learned %s!\n", p.Name, move)
+	default:
+		fmt.Printf("%s wants to learn %s, but already knows %d moves. Use learn to replace one.\n", p.Name, move, learnset.MaxMoves)
+	}
+}
+
+// commandMoves lists the moves a Pokemon can learn, grouped by method.
+func commandMoves(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no Pokemon specified")
+	}
+	data, err := lookupPokemon(ctx, cfg, args[0])
+	if err != nil {
+		return err
+	}
+	group := versionGroup(cfg, data)
+	entries := learnset.For(data, group)
+	if len(entries) == 0 {
+		fmt.Printf("%s learns no moves in %s\n", data.Name, group)
+		return nil
+	}
+	fmt.Printf("Moves for %s in %s:\n", data.Name, group)
+	method := ""
+	for _, e := range entries {
+		if e.Method != method {
+			method = e.Method
+			fmt.Printf("%s:\n", method)
+		}
+		if e.Method == learnset.LevelUp {
+			fmt.Printf(" - Lv. %-3d %s\n", e.Level, e.Move)
+		} else {
+			fmt.Printf(" - %s\n", e.Move)
+		}
+	}
+	return nil
+}
+
+func commandMove(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no move specified")
+	}
+	move, err := cfg.client.GetMove(ctx, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no such move: %s", args[0])
+	} else if err != nil {
+		return fmt.Errorf("error getting move: %w", err)
+	}
+	fmt.Printf("Name: %s\nType: %s\nCategory: %s\n", move.Name, move.Type.Name, move.DamageClass.Name)
+	fmt.Printf("Power: %s\nAccuracy: %s\nPP: %s\n", optional(move.Power), optional(move.Accuracy), optional(move.PP))
+	if move.Priority != 0 {
+		fmt.Printf("Priority: %+d\n", move.Priority)
+	}
+	for _, e := range move.EffectEntries {
+		if e.Language.Name != language {
+			continue
+		}
+		effect := e.ShortEffect
+		if move.EffectChance != nil {
+			effect = strings.ReplaceAll(effect, "$effect_chance", fmt.Sprint(*move.EffectChance))
+		}
+		fmt.Printf("Effect: %s\n", effect)
+		break
+	}
+	return nil
+}
+
+func optional(n *int) string {
+	if n == nil {
+		return "-"
+	}
+	return fmt.Sprint(*n)
+}
+
+// commandLearn teaches a caught Pokemon a level-up move it is high enough
+// level for, replacing a known move if it already knows four.
+func commandLearn(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: learn <pokemon> <move> [move to forget]")
+	}
+	caught, _, _, err := cfg.trainer.Find(args[0])
+	if err != nil {
+		return err
+	}
+	move := args[1]
+	if slices.Contains(caught.Moves, move) {
+		return fmt.Errorf("%s already knows %s", caught, move)
+	}
+	data, err := cfg.client.GetPokemonData(ctx, caught.Name)
+	if err != nil {
+		return fmt.Errorf("error getting Pokemon data: %w", err)
+	}
+	if !learnset.CanLearn(learnset.For(data, versionGroup(cfg, data)), move, caught.Level) {
+		return fmt.Errorf("%s cannot learn %s at level %d", caught, move, caught.Level)
+	}
+	if len(caught.Moves) >= learnset.MaxMoves {
+		if len(args) < 3 {
+			return fmt.Errorf("%s already knows %s, choose one to forget", caught, strings.Join(caught.Moves, ", "))
+		}
+		i := slices.Index(caught.Moves, args[2])
+		if i == -1 {
+			return fmt.Errorf("%s does not know %s", caught, args[2])
+		}
+		fmt.Printf("%s forgot %s.\n", caught.Name, args[2])
+		caught.Moves = slices.Delete(caught.Moves, i, i+1)
+	}
+	caught.Moves = append(caught.Moves, move)
+	fmt.Printf("%s learned %s!\n", caught.Name, move)
+	return autosave(cfg)
+}
+
+func commandForget(_ context.Context, cfg *config, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: forget <pokemon> <move>")
+	}
+	caught, _, _, err := cfg.trainer.Find(args[0])
+	if err != nil {
+		return err
+	}
+	i := slices.Index(caught.Moves, args[1])
+	if i == -1 {
+		return fmt.Errorf("%s does not know %s", caught, args[1])
+	}
+	if len(caught.Moves) == 1 {
+		return fmt.Errorf("%s must know at least one move", caught)
+	}
+	caught.Moves = slices.Delete(caught.Moves, i, i+1)
+	fmt.Printf("%s forgot %s.\n", caught.Name, args[1])
+	return autosave(cfg)
+}
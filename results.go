@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/faust-m/pokedexcli/internal/encounters"
+	"github.com/faust-m/pokedexcli/internal/output"
+)
+
+// render runs a command with a structured result and writes the result in
+// the current output format.
+func render(ctx context.Context, cfg *config, command func(context.Context, *config, ...string) (output.Result, error), args ...string) error {
+	result, err := command(ctx, cfg, args...)
+	if err != nil {
+		return err
+	}
+	return output.Render(os.Stdout, outputFormat(cfg), result)
+}
+
+// outputFormat is the format chosen for the running command with --output,
+// or otherwise the saved format setting.
+func outputFormat(cfg *config) output.Format {
+	if cfg.format != "" {
+		return cfg.format
+	}
+	if cfg.settings.Format != "" {
+		return output.Format(cfg.settings.Format)
+	}
+	return output.Text
+}
+
+// commandFormat shows or sets the output format used by commands with
+// structured results.
+func commandFormat(_ context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		fmt.Printf("Output format: %s\n", outputFormat(cfg))
+		return nil
+	}
+	format, err := output.Parse(args[0])
+	if err != nil {
+		return err
+	}
+	cfg.settings.Format = string(format)
+	fmt.Printf("Output format is now %s\n", format)
+	return autosave(cfg)
+}
+
+type pokedexEntry struct {
+	Name   string `json:"name"`
+	Caught bool   `json:"caught"`
+}
+
+type pokedexResult struct {
+	Seen    int            `json:"seen"`
+	Caught  int            `json:"caught"`
+	Pokemon []pokedexEntry `json:"pokemon"`
+}
+
+func (r pokedexResult) Text(w io.Writer) error {
+	if r.Seen == 0 {
+		_, err := fmt.Fprintln(w, "you have no pokemon in your pokedex")
+		return err
+	}
+	fmt.Fprintf(w, "Pokedex: %d seen, %d caught\n", r.Seen, r.Caught)
+	for _, p := range r.Pokemon {
+		if p.Caught {
+			fmt.Fprintf(w, " - %s (caught)\n", p.Name)
+		} else {
+			fmt.Fprintf(w, " - %s\n", p.Name)
+		}
+	}
+	return nil
+}
+
+func (r pokedexResult) Rows() [][]string {
+	rows := [][]string{{"name", "caught"}}
+	for _, p := range r.Pokemon {
+		rows = append(rows, []string{p.Name, strconv.FormatBool(p.Caught)})
+	}
+	return rows
+}
+
+// exploreResult is the outcome of searching an area. Method is empty if no
+// wild Pokemon live there, and Pokemon if none appeared.
+type exploreResult struct {
+	Area     string `json:"area"`
+	Location string `json:"location"`
+	Version  string `json:"version,omitempty"`
+	Method   string `json:"method,omitempty"`
+	Pokemon  string `json:"pokemon,omitempty"`
+	Level    int    `json:"level,omitempty"`
+}
+
+func (r exploreResult) Text(w io.Writer) error {
+	fmt.Fprintf(w, "Exploring %s...\n", r.Area)
+	switch {
+	case r.Method == "":
+		fmt.Fprintln(w, "There are no wild Pokemon here.")
+	case r.Pokemon == "":
+		fmt.Fprintf(w, "Searching by %s (%s)...\nNothing appeared.\n", r.Method, r.Version)
+	default:
+		fmt.Fprintf(w, "Searching by %s (%s)...\n", r.Method, r.Version)
+		fmt.Fprintf(w, "A wild %s (Lv. %d) appeared!\n", r.Pokemon, r.Level)
+		fmt.Fprintln(w, "Use fight <pokemon> to battle it, catch to throw a Pokeball, or run.")
+	}
+	return nil
+}
+
+func (r exploreResult) Rows() [][]string {
+	level := ""
+	if r.Level > 0 {
+		level = strconv.Itoa(r.Level)
+	}
+	return [][]string{
+		{"area", "location", "version", "method", "pokemon", "level"},
+		{r.Area, r.Location, r.Version, r.Method, r.Pokemon, level},
+	}
+}
+
+// areaResult is an area's encounter table.
+type areaResult struct {
+	Area       string            `json:"area"`
+	Location   string            `json:"location"`
+	Encounters []encounters.Slot `json:"encounters"`
+}
+
+func (r areaResult) Text(w io.Writer) error {
+	fmt.Fprintf(w, "Pokemon in %s:\n", r.Area)
+	for _, s := range r.Encounters {
+		fmt.Fprintf(w, " - %s (%s, %s, %d%%, Lv. %d-%d)\n", s.Pokemon, s.Version, s.Method, s.Chance, s.MinLevel, s.MaxLevel)
+	}
+	return nil
+}
+
+func (r areaResult) Rows() [][]string {
+	rows := [][]string{{"pokemon", "version", "method", "chance", "min_level", "max_level"}}
+	for _, s := range r.Encounters {
+		rows = append(rows, []string{s.Pokemon, s.Version, s.Method, strconv.Itoa(s.Chance), strconv.Itoa(s.MinLevel), strconv.Itoa(s.MaxLevel)})
+	}
+	return rows
+}
+
+type statEntry struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+	Base  int    `json:"base"`
+}
+
+type abilityEntry struct {
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden"`
+}
+
+type heldItemEntry struct {
+	Name   string `json:"name"`
+	Rarity int    `json:"rarity"`
+}
+
+// inspectResult describes a caught Pokemon. The sprite is only drawn as
+// text.
+type inspectResult struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Height     int             `json:"height"`
+	Weight     int             `json:"weight"`
+	Level      int             `json:"level"`
+	Experience int             `json:"experience"`
+	Friendship int             `json:"friendship"`
+	Nature     string          `json:"nature"`
+	Ability    string          `json:"ability,omitempty"`
+	Stats      []statEntry     `json:"stats"`
+	Types      []string        `json:"types"`
+	Abilities  []abilityEntry  `json:"abilities"`
+	Moves      []string        `json:"moves"`
+	HeldItems  []heldItemEntry `json:"held_items"`
+	Sprite     string          `json:"sprite,omitempty"`
+
+	spriteImage image.Image
+}
+
+func (r inspectResult) Text(w io.Writer) error {
+	fmt.Fprintf(w, "ID: %d\nName: %s\nHeight: %v\nWeight: %v\n", r.ID, r.Name, r.Height, r.Weight)
+	fmt.Fprintf(w, "Level: %d\nExperience: %d\nFriendship: %d\nNature: %s\n", r.Level, r.Experience, r.Friendship, r.Nature)
+	if r.Ability != "" {
+		fmt.Fprintf(w, "Ability: %s\n", r.Ability)
+	}
+	fmt.Fprintln(w, "Stats:")
+	for _, s := range r.Stats {
+		fmt.Fprintf(w, " -%s: %v (base %v)\n", s.Name, s.Value, s.Base)
+	}
+	fmt.Fprintln(w, "Types:")
+	for _, t := range r.Types {
+		fmt.Fprintf(w, " - %s\n", t)
+	}
+	fmt.Fprintln(w, "Abilities:")
+	for _, a := range r.Abilities {
+		if a.Hidden {
+			fmt.Fprintf(w, " - %s (hidden)\n", a.Name)
+		} else {
+			fmt.Fprintf(w, " - %s\n", a.Name)
+		}
+	}
+	if len(r.Moves) > 0 {
+		fmt.Fprintln(w, "Moves:")
+		for _, m := range r.Moves {
+			fmt.Fprintf(w, " - %s\n", m)
+		}
+	}
+	if len(r.HeldItems) > 0 {
+		fmt.Fprintln(w, "Held by wild Pokemon:")
+		for _, item := range r.HeldItems {
+			fmt.Fprintf(w, " - %s (%d%%)\n", item.Name, item.Rarity)
+		}
+	}
+	if r.Sprite != "" {
+		fmt.Fprintf(w, "Sprite: %s\n", r.Sprite)
+	}
+	if r.spriteImage != nil {
+		return drawSprite(w, r.spriteImage)
+	}
+	return nil
+}
+
+// Rows lists the Pokemon's attributes as field and value pairs, joining
+// lists with spaces.
+func (r inspectResult) Rows() [][]string {
+	rows := [][]string{
+		{"field", "value"},
+		{"id", strconv.Itoa(r.ID)},
+		{"name", r.Name},
+		{"height", strconv.Itoa(r.Height)},
+		{"weight", strconv.Itoa(r.Weight)},
+		{"level", strconv.Itoa(r.Level)},
+		{"experience", strconv.Itoa(r.Experience)},
+		{"friendship", strconv.Itoa(r.Friendship)},
+		{"nature", r.Nature},
+		{"ability", r.Ability},
+	}
+	for _, s := range r.Stats {
+		rows = append(rows, []string{s.Name, strconv.Itoa(s.Value)})
+	}
+	abilities := make([]string, 0, len(r.Abilities))
+	for _, a := range r.Abilities {
+		if a.Hidden {
+			abilities = append(abilities, a.Name+"(hidden)")
+		} else {
+			abilities = append(abilities, a.Name)
+		}
+	}
+	items := make([]string, 0, len(r.HeldItems))
+	for _, item := range r.HeldItems {
+		items = append(items, fmt.Sprintf("%s(%d%%)", item.Name, item.Rarity))
+	}
+	return append(rows,
+		[]string{"types", strings.Join(r.Types, " ")},
+		[]string{"abilities", strings.Join(abilities, " ")},
+		[]string{"moves", strings.Join(r.Moves, " ")},
+		[]string{"held_items", strings.Join(items, " ")},
+		[]string{"sprite", r.Sprite},
+	)
+}
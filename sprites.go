@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/faust-m/pokedexcli/internal/sprite"
+)
+
+// defaultSpriteWidth is used when standard output is not a terminal.
+const defaultSpriteWidth = 80
+
+// printSprite draws the sprite at spriteURL.
+func printSprite(ctx context.Context, cfg *config, spriteURL string) error {
+	img, err := cfg.client.GetSprite(ctx, spriteURL)
+	if err != nil {
+		return fmt.Errorf("error getting sprite: %w", err)
+	}
+	return drawSprite(os.Stdout, img)
+}
+
+// drawSprite draws img to w sized to fit the terminal, leaving a line for
+// the prompt. When standard output is not a terminal it is drawn in ASCII.
+func drawSprite(w io.Writer, img image.Image) error {
+	mode := sprite.DetectMode(os.Getenv)
+	cols, lines, ok := sprite.TerminalSize(os.Stdout)
+	if !ok {
+		cols, lines, mode = defaultSpriteWidth, 0, sprite.ASCII
+	}
+	return sprite.Render(w, img, cols, max(lines-1, 0), mode)
+}
+
+// commandSprite draws a Pokemon's sprite for the chosen game, from the
+// front by default.
+func commandSprite(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: sprite <pokemon> [shiny] [back]")
+	}
+	for _, option := range args[1:] {
+		if option != "shiny" && option != "back" {
+			return fmt.Errorf("unknown sprite option %s, use shiny or back", option)
+		}
+	}
+	data, err := lookupPokemon(ctx, cfg, args[0])
+	if err != nil {
+		return err
+	}
+	sprites, _ := data.VersionSprites(cfg.settings.Generation, cfg.settings.VersionGroup)
+	shiny, back := slices.Contains(args[1:], "shiny"), slices.Contains(args[1:], "back")
+	var spriteURL string
+	switch {
+	case shiny && back:
+		spriteURL = sprites.BackShiny
+	case shiny:
+		spriteURL = sprites.FrontShiny
+	case back:
+		spriteURL = sprites.BackDefault
+	default:
+		spriteURL = sprites.FrontDefault
+	}
+	if spriteURL == "" {
+		return fmt.Errorf("there is no such sprite of %s", data.Name)
+	}
+	return printSprite(ctx, cfg, spriteURL)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/faust-m/pokedexcli/internal/trainer"
+)
+
+func commandParty(_ context.Context, cfg *config, _ ...string) error {
+	if len(cfg.trainer.Party) == 0 {
+		fmt.Println("your party is empty")
+		return nil
+	}
+	fmt.Println("Party:")
+	for i, p := range cfg.trainer.Party {
+		fmt.Printf(" %d. %s\n", i+1, p)
+	}
+	return nil
+}
+
+func commandBox(_ context.Context, cfg *config, args ...string) error {
+	boxes := cfg.trainer.Boxes
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > len(boxes) {
+			return fmt.Errorf("no such box: %s", args[0])
+		}
+		printBox(n, boxes[n-1])
+		return nil
+	}
+	if len(cfg.trainer.All()) == len(cfg.trainer.Party) {
+		fmt.Println("your PC boxes are empty")
+		return nil
+	}
+	for i, box := range boxes {
+		if len(box) > 0 {
+			printBox(i+1, box)
+		}
+	}
+	return nil
+}
+
+func printBox(n int, box []*trainer.Pokemon) {
+	fmt.Printf("Box %d (%d/%d):\n", n, len(box), trainer.BoxSize)
+	for _, p := range box {
+		fmt.Printf(" - %s\n", p)
+	}
+}
+
+func commandDeposit(_ context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no Pokemon specified to deposit")
+	}
+	if err := checkNotFighting(cfg, args[0]); err != nil {
+		return err
+	}
+	p, box, err := cfg.trainer.Deposit(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s was deposited in box %d.\n", p, box+1)
+	return autosave(cfg)
+}
+
+func commandWithdraw(_ context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no Pokemon specified to withdraw")
+	}
+	p, err := cfg.trainer.Withdraw(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s joined your party.\n", p)
+	return autosave(cfg)
+}
+
+func commandSwap(_ context.Context, cfg *config, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: swap <pokemon> <pokemon>")
+	}
+	if err := cfg.trainer.Swap(args[0], args[1]); err != nil {
+		return err
+	}
+	fmt.Printf("Swapped %s and %s.\n", args[0], args[1])
+	return autosave(cfg)
+}
+
+func commandRelease(_ context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no Pokemon specified to release")
+	}
+	if err := checkNotFighting(cfg, args[0]); err != nil {
+		return err
+	}
+	p, err := cfg.trainer.Release(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s was released. Bye-bye, %s!\n", p, p.Name)
+	return autosave(cfg)
+}
+
+// checkNotFighting returns an error if ref is the Pokemon sent out against
+// the current wild Pokemon.
+func checkNotFighting(cfg *config, ref string) error {
+	if cfg.encounter == nil || cfg.encounter.fighter == nil {
+		return nil
+	}
+	if p, _, _, err := cfg.trainer.Find(ref); err == nil && p == cfg.encounter.fighter {
+		return fmt.Errorf("%s is battling the wild %s, win or run first", p, cfg.encounter.pokemon.Name)
+	}
+	return nil
+}
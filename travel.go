@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+	"github.com/faust-m/pokedexcli/internal/trainer"
+)
+
+const mapPageSize = 20
+
+// startPosition is where a new trainer begins.
+var startPosition = trainer.Position{Region: "sinnoh", Location: "canalave-city"}
+
+// position returns where the trainer is, starting them off if they have not
+// travelled yet.
+func position(cfg *config) trainer.Position {
+	if cfg.trainer.Position.Region == "" {
+		cfg.trainer.Position = startPosition
+	}
+	return cfg.trainer.Position
+}
+
+func currentRegion(ctx context.Context, cfg *config) (pokeapi.Region, error) {
+	region, err := cfg.client.GetRegion(ctx, position(cfg).Region)
+	if err != nil {
+		return pokeapi.Region{}, fmt.Errorf("error getting region: %w", err)
+	}
+	return region, nil
+}
+
+func currentLocation(ctx context.Context, cfg *config) (pokeapi.Location, error) {
+	location, err := cfg.client.GetLocation(ctx, position(cfg).Location)
+	if err != nil {
+		return pokeapi.Location{}, fmt.Errorf("error getting location: %w", err)
+	}
+	return location, nil
+}
+
+func displayName(names []pokeapi.Name, name string) string {
+	if localized := pokeapi.LocalizedName(names, language); localized != "" {
+		return localized
+	}
+	return name
+}
+
+// commandTravel moves to a location in the current region, or to the first
+// location of another region.
+func commandTravel(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no location specified to travel to")
+	}
+	if cfg.encounter != nil {
+		return fmt.Errorf("you cannot travel while a wild %s is in front of you", cfg.encounter.pokemon.Name)
+	}
+	region, err := currentRegion(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	destination := trainer.Position{Region: region.Name, Location: args[0]}
+	if !slices.ContainsFunc(region.Locations, func(l pokeapi.NamedResource) bool { return l.Name == args[0] }) {
+		other, err := cfg.client.GetRegion(ctx, args[0])
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			return fmt.Errorf("%s is not a place you can reach from %s", args[0], displayName(region.Names, region.Name))
+		} else if err != nil {
+			return fmt.Errorf("error getting region: %w", err)
+		}
+		if len(other.Locations) == 0 {
+			return fmt.Errorf("there is nowhere to go in %s", other.Name)
+		}
+		destination = trainer.Position{Region: other.Name, Location: other.Locations[0].Name}
+	}
+	if destination == position(cfg) {
+		return fmt.Errorf("you are already in %s", destination.Location)
+	}
+	if destination.Region != position(cfg).Region {
+		cfg.mapPage = 0
+	}
+	cfg.trainer.Position = destination
+	if err := commandWhere(ctx, cfg); err != nil {
+		return err
+	}
+	return autosave(cfg)
+}
+
+func commandWhere(ctx context.Context, cfg *config, _ ...string) error {
+	region, err := currentRegion(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	location, err := currentLocation(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("You are in %s, %s.\n", displayName(location.Names, location.Name), displayName(region.Names, region.Name))
+	if len(location.Areas) > 0 {
+		fmt.Println("Areas to explore:")
+		for _, area := range location.Areas {
+			fmt.Printf(" - %s\n", area.Name)
+		}
+	}
+	return nil
+}
+
+func commandMap(ctx context.Context, cfg *config, _ ...string) error {
+	region, err := currentRegion(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.mapPage*mapPageSize >= len(region.Locations) {
+		fmt.Println("You're on the last page!")
+		return nil
+	}
+	if err := printMapPage(ctx, cfg, region, cfg.mapPage); err != nil {
+		return err
+	}
+	cfg.mapPage++
+	return nil
+}
+
+func commandMapb(ctx context.Context, cfg *config, _ ...string) error {
+	if cfg.mapPage < 2 {
+		fmt.Println("You're on the first page!")
+		return nil
+	}
+	region, err := currentRegion(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if err := printMapPage(ctx, cfg, region, cfg.mapPage-2); err != nil {
+		return err
+	}
+	cfg.mapPage--
+	return nil
+}
+
+// printMapPage lists the areas in one page of the region's locations.
+func printMapPage(ctx context.Context, cfg *config, region pokeapi.Region, page int) error {
+	start := page * mapPageSize
+	end := min(start+mapPageSize, len(region.Locations))
+	for _, l := range region.Locations[start:end] {
+		location, err := cfg.client.GetLocation(ctx, l.Name)
+		if err != nil {
+			return fmt.Errorf("error getting location %s: %w", l.Name, err)
+		}
+		for _, area := range location.Areas {
+			fmt.Println(area.Name)
+		}
+	}
+	return nil
+}
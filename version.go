@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/faust-m/pokedexcli/internal/pokeapi"
+)
+
+// commandVersion shows the chosen game version, or chooses one along with
+// its version group and generation.
+func commandVersion(ctx context.Context, cfg *config, args ...string) error {
+	if len(args) == 0 {
+		if cfg.settings.GameVersion == "" {
+			fmt.Println("No game version chosen, data from every game is used.")
+		} else {
+			fmt.Printf("Playing %s (%s, %s)\n", cfg.settings.GameVersion, cfg.settings.VersionGroup, cfg.settings.Generation)
+		}
+		return nil
+	}
+	version, err := cfg.client.GetVersion(ctx, args[0])
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no such version: %s, use versions to list them", args[0])
+	} else if err != nil {
+		return fmt.Errorf("error getting version: %w", err)
+	}
+	group, err := cfg.client.GetVersionGroup(ctx, version.VersionGroup.Name)
+	if err != nil {
+		return fmt.Errorf("error getting version group: %w", err)
+	}
+	cfg.settings.GameVersion = version.Name
+	cfg.settings.VersionGroup = group.Name
+	cfg.settings.Generation = group.Generation.Name
+	var regions []string
+	for _, r := range group.Regions {
+		regions = append(regions, r.Name)
+	}
+	fmt.Printf("Now playing %s (%s, %s)\n", displayName(version.Names, version.Name), group.Name, group.Generation.Name)
+	if len(regions) > 0 {
+		fmt.Printf("Regions: %s\n", strings.Join(regions, ", "))
+	}
+	return autosave(cfg)
+}
+
+func commandVersions(ctx context.Context, cfg *config, _ ...string) error {
+	versions, err := cfg.client.GetVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing versions: %w", err)
+	}
+	for _, v := range versions.Results {
+		if v.Name == cfg.settings.GameVersion {
+			fmt.Printf(" * %s\n", v.Name)
+		} else {
+			fmt.Printf(" - %s\n", v.Name)
+		}
+	}
+	return nil
+}